@@ -0,0 +1,62 @@
+package ghfilter
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+// MatchesChan reads events from in and calls fn with batches of the matching
+// events. A batch is emitted once it contains size events, or interval after the
+// first event was added to the batch, whichever is first. Events within a batch
+// are in the order they were received from in, and fn is called sequentially.
+//
+// When in is closed, any remaining matched events are emitted and nil is
+// returned. If ctx is cancelled, ctx's error is returned and any remaining
+// matched events are discarded.
+func (f *Filter) MatchesChan(ctx context.Context, in <-chan *github.Event, size int, interval time.Duration, fn func([]*github.Event)) error {
+	var (
+		batch []*github.Event
+		timer *time.Timer
+		flush <-chan time.Time // flush is nil when there's no pending batch
+	)
+
+	emit := func() {
+		if timer != nil {
+			timer.Stop()
+			timer, flush = nil, nil
+		}
+		if len(batch) > 0 {
+			fn(batch)
+			batch = nil
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			return ctx.Err()
+		case <-flush:
+			emit()
+		case event, ok := <-in:
+			if !ok {
+				emit()
+				return nil
+			}
+			if !f.Matches(event) {
+				continue
+			}
+			batch = append(batch, event)
+			if len(batch) >= size {
+				emit()
+			} else if timer == nil {
+				timer = time.NewTimer(interval)
+				flush = timer.C
+			}
+		}
+	}
+}
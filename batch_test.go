@@ -0,0 +1,73 @@
+package ghfilter
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+func TestFilter_matchesChanSize(t *testing.T) {
+	filter := Filter{Conditions: []Condition{{Type: "IssuesEvent"}}}
+
+	in := make(chan *github.Event)
+	go func() {
+		for i := 0; i < 5; i++ {
+			in <- &github.Event{ID: github.String(string(rune('a' + i))), Type: github.String("IssuesEvent")}
+			in <- &github.Event{Type: github.String("PushEvent")}
+		}
+		close(in)
+	}()
+
+	var have [][]string
+	err := filter.MatchesChan(context.Background(), in, 2, time.Hour, func(events []*github.Event) {
+		var ids []string
+		for _, event := range events {
+			ids = append(ids, event.GetID())
+		}
+		have = append(have, ids)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := [][]string{{"a", "b"}, {"c", "d"}, {"e"}} // e is flushed on close
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("batches do not match\nhave: %v\nwant: %v", have, want)
+	}
+}
+
+func TestFilter_matchesChanInterval(t *testing.T) {
+	var (
+		filter  = Filter{}
+		in      = make(chan *github.Event)
+		batches = make(chan int)
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error)
+	go func() {
+		done <- filter.MatchesChan(ctx, in, 100, 10*time.Millisecond, func(events []*github.Event) {
+			batches <- len(events)
+		})
+	}()
+
+	in <- &github.Event{}
+	in <- &github.Event{}
+
+	select {
+	case n := <-batches:
+		if n != 2 {
+			t.Errorf("have batch of %v events, want 2", n)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timeout waiting for interval flush")
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("have error: %v, want: %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,121 @@
+package ghfilter
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+// burstKey identifies the issue or pull request a labeled event applies to.
+type burstKey struct {
+	repo   string
+	number int
+}
+
+// LabelBurstFilter wraps a Filter and matches when many labels are added to the
+// same issue or pull request within a time window, such as for auditing bulk
+// label changes.
+//
+// GitHub emits a separate labeled event for each label added, even when many
+// labels are added at once, and an event's payload only contains the single
+// label added. So the number of labels changed cannot be compared by a
+// Condition, and a LabelBurstFilter instead counts the labeled events for each
+// issue or pull request it receives.
+//
+// At most DefaultCacheCapacity issues and pull requests are remembered, see
+// SetCapacity. A LabelBurstFilter is safe for concurrent use.
+type LabelBurstFilter struct {
+	filter *Filter
+	n      int
+	window time.Duration
+	now    func() time.Time // now returns the current time, replaced in tests
+
+	mu       sync.Mutex
+	capacity int
+	// seen is the []time.Time of each labeled event within the window for each
+	// burstKey, oldest first.
+	seen *lruCache
+}
+
+// NewLabelBurstFilter returns a LabelBurstFilter which matches labeled events
+// matching filter once at least n such events for the same repository and
+// issue or pull request number have been received within window.
+func NewLabelBurstFilter(filter *Filter, n int, window time.Duration) *LabelBurstFilter {
+	return &LabelBurstFilter{
+		filter:   filter,
+		n:        n,
+		window:   window,
+		now:      time.Now,
+		capacity: DefaultCacheCapacity,
+		seen:     newLRUCache(DefaultCacheCapacity),
+	}
+}
+
+// SetCapacity sets the maximum number of issues and pull requests remembered
+// to n, forgetting all previously received labeled events. When the capacity
+// is exceeded the issue or pull request least recently labeled is forgotten.
+func (b *LabelBurstFilter) SetCapacity(n int) {
+	b.mu.Lock()
+	b.capacity = n
+	b.seen = newLRUCache(n)
+	b.mu.Unlock()
+}
+
+// Matches returns true if event is a labeled event matching the wrapped filter
+// and, including event, at least n labeled events for the same repository and
+// issue or pull request number have matched the wrapped filter within the
+// window, else return false.
+func (b *LabelBurstFilter) Matches(event *github.Event) bool {
+	p := lazyPayload{event: event}
+	payload, ok := p.get("action", "issue", "pull_request", "number")
+	if !ok || payload.Action != "labeled" || payload.number() == 0 {
+		return false
+	}
+	if !b.filter.Matches(event) {
+		return false
+	}
+	key := burstKey{number: payload.number()}
+	if event.Repo != nil {
+		key.repo = event.Repo.GetName()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.expire(now)
+	var times []time.Time
+	if value, ok := b.seen.get(key); ok {
+		times = b.within(value.([]time.Time), now)
+	}
+	times = append(times, now)
+	b.seen.set(key, times)
+	return len(times) >= b.n
+}
+
+// expire removes keys whose most recent event's window has passed, least
+// recently labeled first, b.mu must be held. Times of keys which have not
+// expired are removed by within when the key is next labeled.
+func (b *LabelBurstFilter) expire(now time.Time) {
+	b.seen.removeOldest(func(value interface{}) bool {
+		times := value.([]time.Time)
+		return now.Sub(times[len(times)-1]) >= b.window
+	})
+}
+
+// within returns the times, oldest first, whose window has not passed.
+func (b *LabelBurstFilter) within(times []time.Time, now time.Time) []time.Time {
+	i := 0
+	for i < len(times) && now.Sub(times[i]) >= b.window {
+		i++
+	}
+	return times[i:]
+}
+
+// Reset forgets all previously received labeled events.
+func (b *LabelBurstFilter) Reset() {
+	b.mu.Lock()
+	b.seen = newLRUCache(b.capacity)
+	b.mu.Unlock()
+}
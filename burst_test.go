@@ -0,0 +1,97 @@
+package ghfilter
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+func TestLabelBurstFilter(t *testing.T) {
+	var (
+		label1   = json.RawMessage(`{"action":"labeled","issue":{"number":1},"label":{"name":"bug"}}`)
+		label2   = json.RawMessage(`{"action":"labeled","issue":{"number":2},"label":{"name":"bug"}}`)
+		unlabel1 = json.RawMessage(`{"action":"unlabeled","issue":{"number":1},"label":{"name":"bug"}}`)
+		now      = time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+		repo     = &github.Repository{Name: github.String("owner/repo")}
+		other    = &github.Repository{Name: github.String("owner/other")}
+		issue1   = &github.Event{Type: github.String("IssuesEvent"), Repo: repo, RawPayload: &label1}
+		issue2   = &github.Event{Type: github.String("IssuesEvent"), Repo: repo, RawPayload: &label2}
+		other1   = &github.Event{Type: github.String("IssuesEvent"), Repo: other, RawPayload: &label1}
+		removed1 = &github.Event{Type: github.String("IssuesEvent"), Repo: repo, RawPayload: &unlabel1}
+		pr1      = &github.Event{Type: github.String("PullRequestEvent"), Repo: repo, RawPayload: &label1}
+	)
+
+	burst := NewLabelBurstFilter(&Filter{Conditions: []Condition{{Type: "IssuesEvent"}}}, 3, time.Minute)
+	burst.now = func() time.Time { return now }
+
+	tests := []struct {
+		elapsed time.Duration // elapsed is added to now before matching
+		event   *github.Event
+		want    bool
+	}{
+		{0, issue1, false},
+		{time.Second, issue1, false},
+		{0, issue2, false},   // different number
+		{0, other1, false},   // different repository
+		{0, removed1, false}, // not labeled
+		{0, pr1, false},      // does not match filter
+		{time.Second, issue1, true},
+		{time.Second, issue1, true}, // burst continues
+		{58 * time.Second, issue1, true},
+		{time.Second, issue1, true},      // first two events have expired
+		{2 * time.Minute, issue1, false}, // burst ended
+		{0, issue2, false},
+	}
+
+	for i, test := range tests {
+		now = now.Add(test.elapsed)
+		if have := burst.Matches(test.event); have != test.want {
+			t.Errorf("test %d: have: %v, want: %v", i, have, test.want)
+		}
+	}
+
+	burst.Reset()
+	burst.Matches(issue1)
+	burst.Matches(issue1)
+	if burst.Matches(&github.Event{Type: github.String("IssuesEvent"), Repo: repo}) {
+		t.Errorf("expected event without payload not to match")
+	}
+	if !burst.Matches(issue1) {
+		t.Errorf("expected match after three events")
+	}
+}
+
+func TestLabelBurstFilter_capacity(t *testing.T) {
+	var (
+		label1 = json.RawMessage(`{"action":"labeled","issue":{"number":1},"label":{"name":"bug"}}`)
+		label2 = json.RawMessage(`{"action":"labeled","issue":{"number":2},"label":{"name":"bug"}}`)
+		now    = time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+		issue1 = &github.Event{Type: github.String("IssuesEvent"), RawPayload: &label1}
+		issue2 = &github.Event{Type: github.String("IssuesEvent"), RawPayload: &label2}
+	)
+
+	burst := NewLabelBurstFilter(&Filter{}, 2, time.Minute)
+	burst.now = func() time.Time { return now }
+	burst.SetCapacity(1)
+
+	tests := []struct {
+		event *github.Event
+		want  bool
+	}{
+		{issue1, false},
+		{issue2, false}, // issue1 is forgotten
+		{issue1, false},
+		{issue1, true},
+	}
+
+	for i, test := range tests {
+		if have := burst.Matches(test.event); have != test.want {
+			t.Errorf("test %d: have: %v, want: %v", i, have, test.want)
+		}
+	}
+	if have := burst.seen.len(); have != 1 {
+		t.Errorf("have %v keys, want 1", have)
+	}
+}
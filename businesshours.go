@@ -0,0 +1,96 @@
+package ghfilter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BusinessHours is a daily window of hours in a location, such as 09:00 to
+// 17:00 in America/New_York, used by a condition's BusinessHours.
+type BusinessHours struct {
+	// Start is the hour of the day the window starts, inclusive, from 0 to 23.
+	Start int
+	// End is the hour of the day the window ends, exclusive, from 0 to 23. If
+	// End is before Start the window crosses midnight, such as 22:00 to 06:00.
+	// If End equals Start the window is the entire day.
+	End int
+	// Location is the time zone of Start and End. A nil Location is UTC.
+	Location *time.Location
+}
+
+// contains returns true if t is within the window.
+func (b *BusinessHours) contains(t time.Time) bool {
+	hour := t.In(b.location()).Hour()
+	switch {
+	case b.Start < b.End:
+		return hour >= b.Start && hour < b.End
+	case b.Start > b.End:
+		return hour >= b.Start || hour < b.End
+	}
+	return true
+}
+
+// location returns the window's time zone.
+func (b *BusinessHours) location() *time.Location {
+	if b.Location == nil {
+		return time.UTC
+	}
+	return b.Location
+}
+
+// String returns the window such as "09:00 and 17:00 America/New_York".
+func (b *BusinessHours) String() string {
+	return fmt.Sprintf("%02d:00 and %02d:00 %s", b.Start, b.End, b.location())
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, encoding the
+// window such as "09:00-17:00 America/New_York".
+func (b *BusinessHours) MarshalText() ([]byte, error) {
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+	return []byte(fmt.Sprintf("%02d:00-%02d:00 %s", b.Start, b.End, b.location())), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, decoding a
+// window encoded by MarshalText. The location is loaded using
+// time.LoadLocation.
+func (b *BusinessHours) UnmarshalText(text []byte) error {
+	var (
+		hours       BusinessHours
+		window, loc string
+	)
+	fields := strings.Fields(string(text))
+	switch len(fields) {
+	case 2:
+		loc = fields[1]
+		fallthrough
+	case 1:
+		window = fields[0]
+	default:
+		return fmt.Errorf("invalid business hours %q", text)
+	}
+	if _, err := fmt.Sscanf(window, "%d:00-%d:00", &hours.Start, &hours.End); err != nil {
+		return fmt.Errorf("invalid business hours %q", text)
+	}
+	if loc != "" {
+		var err error
+		if hours.Location, err = time.LoadLocation(loc); err != nil {
+			return err
+		}
+	}
+	if err := hours.validate(); err != nil {
+		return err
+	}
+	*b = hours
+	return nil
+}
+
+// validate returns an error if Start or End is not a valid hour of the day.
+func (b *BusinessHours) validate() error {
+	if b.Start < 0 || b.Start > 23 || b.End < 0 || b.End > 23 {
+		return fmt.Errorf("business hours %d to %d must be between 0 and 23", b.Start, b.End)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package ghfilter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+func TestCondition_businessHours(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("could not load location: %v", err)
+	}
+
+	var (
+		office    = &BusinessHours{Start: 9, End: 17, Location: newYork}
+		overnight = &BusinessHours{Start: 22, End: 6}
+	)
+
+	tests := []struct {
+		hours     *BusinessHours
+		createdAt *time.Time
+		want      bool
+	}{
+		{hours: office, createdAt: nil, want: false},
+		// 09:00 EST is 14:00 UTC
+		{hours: office, createdAt: timePtr(time.Date(2018, 1, 15, 14, 0, 0, 0, time.UTC)), want: true},
+		{hours: office, createdAt: timePtr(time.Date(2018, 1, 15, 13, 59, 59, 0, time.UTC)), want: false},
+		// 17:00 EST is 22:00 UTC
+		{hours: office, createdAt: timePtr(time.Date(2018, 1, 15, 21, 59, 59, 0, time.UTC)), want: true},
+		{hours: office, createdAt: timePtr(time.Date(2018, 1, 15, 22, 0, 0, 0, time.UTC)), want: false},
+		// 09:00 EDT, after daylight saving time starts, is 13:00 UTC
+		{hours: office, createdAt: timePtr(time.Date(2018, 3, 12, 13, 0, 0, 0, time.UTC)), want: true},
+		{hours: office, createdAt: timePtr(time.Date(2018, 3, 12, 21, 0, 0, 0, time.UTC)), want: false},
+		// Crossing midnight, in UTC
+		{hours: overnight, createdAt: timePtr(time.Date(2018, 1, 15, 21, 59, 59, 0, time.UTC)), want: false},
+		{hours: overnight, createdAt: timePtr(time.Date(2018, 1, 15, 22, 0, 0, 0, time.UTC)), want: true},
+		{hours: overnight, createdAt: timePtr(time.Date(2018, 1, 16, 0, 30, 0, 0, time.UTC)), want: true},
+		{hours: overnight, createdAt: timePtr(time.Date(2018, 1, 16, 6, 0, 0, 0, time.UTC)), want: false},
+		// Entire day
+		{hours: &BusinessHours{Start: 8, End: 8}, createdAt: timePtr(time.Date(2018, 1, 16, 3, 0, 0, 0, time.UTC)), want: true},
+	}
+
+	for _, test := range tests {
+		condition := Condition{BusinessHours: test.hours}
+		have := condition.Matches(&github.Event{CreatedAt: test.createdAt})
+		if have != test.want {
+			t.Errorf("hours %v created at %v have: %v, want: %v", test.hours, test.createdAt, have, test.want)
+		}
+	}
+}
+
+func TestBusinessHours_text(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("could not load location: %v", err)
+	}
+
+	condition := Condition{BusinessHours: &BusinessHours{Start: 9, End: 17, Location: newYork}}
+	text, err := condition.MarshalText()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `BusinessHours="09:00-17:00 America/New_York"`; string(text) != want {
+		t.Errorf("have: %s, want: %s", text, want)
+	}
+
+	var decoded Condition
+	if err := decoded.UnmarshalText(text); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decoded.BusinessHours.String() != condition.BusinessHours.String() {
+		t.Errorf("have: %v, want: %v", decoded.BusinessHours, condition.BusinessHours)
+	}
+
+	for _, invalid := range []string{"", "9-17", "09:00-24:00", "09:00-17:00 Nowhere/Unknown"} {
+		if err := new(BusinessHours).UnmarshalText([]byte(invalid)); err == nil {
+			t.Errorf("expected error decoding %q", invalid)
+		}
+	}
+}
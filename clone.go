@@ -0,0 +1,83 @@
+package ghfilter
+
+// clone returns a copy of c which shares no slices or pointers with c, other
+// than regular expressions cached by Compile, which are not modified.
+func (c Condition) clone() Condition {
+	c.PayloadIssueAuthorAssociations = copyStrings(c.PayloadIssueAuthorAssociations)
+	c.PayloadActionAny = copyStrings(c.PayloadActionAny)
+	c.PayloadRepositoryTopicsAny = copyStrings(c.PayloadRepositoryTopicsAny)
+	c.PayloadWorkflowJobLabelsAny = copyStrings(c.PayloadWorkflowJobLabelsAny)
+	c.PayloadIssueLabels = copyStrings(c.PayloadIssueLabels)
+	c.Types = copyStrings(c.Types)
+	if c.PayloadPullRequestMerged != nil {
+		merged := *c.PayloadPullRequestMerged
+		c.PayloadPullRequestMerged = &merged
+	}
+	if c.PayloadJSONPathBool != nil {
+		path := *c.PayloadJSONPathBool
+		c.PayloadJSONPathBool = &path
+	}
+	if c.BusinessHours != nil {
+		hours := *c.BusinessHours
+		c.BusinessHours = &hours
+	}
+	return c
+}
+
+// copyStrings returns a copy of strs, or nil if strs is nil.
+func copyStrings(strs []string) []string {
+	if strs == nil {
+		return nil
+	}
+	return append([]string(nil), strs...)
+}
+
+// WithNegate returns a copy of c with Negate set to negate.
+func (c Condition) WithNegate(negate bool) Condition {
+	c = c.clone()
+	c.Negate = negate
+	return c
+}
+
+// WithType returns a copy of c with Type set to t.
+func (c Condition) WithType(t string) Condition {
+	c = c.clone()
+	c.Type = t
+	return c
+}
+
+// WithPayloadAction returns a copy of c with PayloadAction set to action.
+func (c Condition) WithPayloadAction(action string) Condition {
+	c = c.clone()
+	c.PayloadAction = action
+	return c
+}
+
+// WithPayloadActionAny returns a copy of c with PayloadActionAny set to a copy
+// of actions.
+func (c Condition) WithPayloadActionAny(actions ...string) Condition {
+	c = c.clone()
+	c.PayloadActionAny = copyStrings(actions)
+	return c
+}
+
+// WithPayloadIssueLabel returns a copy of c with PayloadIssueLabel set to label.
+func (c Condition) WithPayloadIssueLabel(label string) Condition {
+	c = c.clone()
+	c.PayloadIssueLabel = label
+	return c
+}
+
+// WithOrganizationID returns a copy of c with OrganizationID set to id.
+func (c Condition) WithOrganizationID(id int) Condition {
+	c = c.clone()
+	c.OrganizationID = id
+	return c
+}
+
+// WithRepositoryID returns a copy of c with RepositoryID set to id.
+func (c Condition) WithRepositoryID(id int) Condition {
+	c = c.clone()
+	c.RepositoryID = id
+	return c
+}
@@ -0,0 +1,61 @@
+package ghfilter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCondition_with(t *testing.T) {
+	template := Condition{
+		Type:                       "IssuesEvent",
+		PayloadActionAny:           []string{"opened", "reopened"},
+		PayloadRepositoryTopicsAny: []string{"go"},
+	}
+
+	var (
+		repo1   = template.WithRepositoryID(1)
+		repo2   = template.WithRepositoryID(2).WithPayloadIssueLabel("bug")
+		pr      = template.WithType("PullRequestEvent").WithPayloadActionAny("closed")
+		negated = template.WithNegate(true)
+	)
+
+	// Mutating a derived condition must not affect the template or siblings
+	repo1.PayloadRepositoryTopicsAny[0] = "rust"
+
+	want := Condition{
+		Type:                       "IssuesEvent",
+		PayloadActionAny:           []string{"opened", "reopened"},
+		PayloadRepositoryTopicsAny: []string{"go"},
+	}
+	if !reflect.DeepEqual(template, want) {
+		t.Errorf("template modified\nhave: %+v\nwant: %+v", template, want)
+	}
+
+	tests := []struct {
+		have Condition
+		want Condition
+	}{
+		{
+			have: repo1,
+			want: Condition{Type: "IssuesEvent", PayloadActionAny: []string{"opened", "reopened"}, PayloadRepositoryTopicsAny: []string{"rust"}, RepositoryID: 1},
+		},
+		{
+			have: repo2,
+			want: Condition{Type: "IssuesEvent", PayloadActionAny: []string{"opened", "reopened"}, PayloadRepositoryTopicsAny: []string{"go"}, RepositoryID: 2, PayloadIssueLabel: "bug"},
+		},
+		{
+			have: pr,
+			want: Condition{Type: "PullRequestEvent", PayloadActionAny: []string{"closed"}, PayloadRepositoryTopicsAny: []string{"go"}},
+		},
+		{
+			have: negated,
+			want: Condition{Negate: true, Type: "IssuesEvent", PayloadActionAny: []string{"opened", "reopened"}, PayloadRepositoryTopicsAny: []string{"go"}},
+		},
+	}
+
+	for _, test := range tests {
+		if !reflect.DeepEqual(test.have, test.want) {
+			t.Errorf("derived condition does not match\nhave: %+v\nwant: %+v", test.have, test.want)
+		}
+	}
+}
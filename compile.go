@@ -0,0 +1,107 @@
+package ghfilter
+
+import (
+	"encoding/json"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+// CompiledFilter is a Filter which has been validated and prepared for
+// repeated matching against many events. Use Compile to create one.
+type CompiledFilter struct {
+	filter Filter
+
+	// profile holds the total nanoseconds spent in each condition, indexed
+	// by the condition's position in the filter. It is nil unless profiling
+	// is enabled and is only accessed atomically.
+	profile []int64
+}
+
+// Compile validates filter and returns a CompiledFilter, whose conditions'
+// regular expressions are compiled once, see Filter.Compile. An error is
+// returned if any condition contains an invalid regular expression.
+func Compile(filter Filter) (*CompiledFilter, error) {
+	conditions := make([]Condition, len(filter.Conditions))
+	for i, condition := range filter.Conditions {
+		conditions[i] = condition.clone()
+	}
+	var root *CompositeNode
+	if filter.Root != nil {
+		root = filter.Root.clone()
+	}
+	funcs := make([]func(*github.Event) bool, len(filter.Funcs))
+	copy(funcs, filter.Funcs)
+	cf := &CompiledFilter{filter: Filter{
+		Conditions:   conditions,
+		Logic:        filter.Logic,
+		Root:         root,
+		Funcs:        funcs,
+		ExcludeTypes: copyStrings(filter.ExcludeTypes),
+	}}
+	if err := cf.filter.Compile(); err != nil {
+		return nil, err
+	}
+	return cf, nil
+}
+
+// EnableProfiling records the time spent evaluating each condition on all
+// subsequent calls to Matches, see Profile. It must be called before Matches
+// is used concurrently.
+func (cf *CompiledFilter) EnableProfiling() {
+	if cf.profile == nil {
+		cf.profile = make([]int64, len(cf.filter.Conditions))
+	}
+}
+
+// Profile returns the total time spent evaluating each condition, keyed by
+// the condition's index, since profiling was enabled. Conditions skipped due
+// to an earlier condition determining the result do not accumulate time.
+// Returns nil if profiling is not enabled.
+func (cf *CompiledFilter) Profile() map[int]time.Duration {
+	if cf.profile == nil {
+		return nil
+	}
+	profile := make(map[int]time.Duration, len(cf.profile))
+	for i := range cf.profile {
+		profile[i] = time.Duration(atomic.LoadInt64(&cf.profile[i]))
+	}
+	return profile
+}
+
+// Matches returns true if event matches the filter, see Filter.Matches.
+func (cf *CompiledFilter) Matches(event *github.Event) bool {
+	if cf.profile == nil {
+		return cf.filter.Matches(event)
+	}
+	if cf.filter.excludes(event) {
+		return false
+	}
+	return cf.filter.matchesConditions(func(i int, condition *Condition) bool {
+		start := time.Now()
+		matched := condition.Matches(event)
+		atomic.AddInt64(&cf.profile[i], int64(time.Since(start)))
+		return matched
+	}) && cf.filter.matchesRoot(event, time.Now) && cf.filter.matchesFuncs(event)
+}
+
+// MatchesJSONL reads newline delimited JSON encoded events from r, such as an
+// exported event log, and calls fn with each event that matches. A single
+// decoder is reused for all events. Returns the first error decoding an event,
+// or nil when r has been read in full.
+func (cf *CompiledFilter) MatchesJSONL(r io.Reader, fn func(*github.Event)) error {
+	dec := json.NewDecoder(r)
+	for {
+		event := new(github.Event)
+		if err := dec.Decode(event); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if cf.Matches(event) {
+			fn(event)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package ghfilter
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+func TestCompile_invalidRegexp(t *testing.T) {
+	_, err := Compile(Filter{Conditions: []Condition{{PayloadIssueTitleRegexp: "("}}})
+	if err == nil {
+		t.Errorf("expected error for invalid regexp")
+	}
+}
+
+func TestCondition_compile(t *testing.T) {
+	payload := json.RawMessage(`{"issue":{"title":"This will Match","body":"cc @bradleyfalzon"}}`)
+	event := &github.Event{Type: github.String("IssuesEvent"), RawPayload: &payload}
+
+	condition := Condition{
+		PayloadIssueTitleRegexp: `(?i)will\s+match`,
+		PayloadBodyMentions:     "bradleyfalzon",
+	}
+	if err := condition.Compile(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(condition.compiled) != 2 {
+		t.Errorf("have %d compiled regexps, want 2", len(condition.compiled))
+	}
+	if !condition.Matches(event) {
+		t.Errorf("expected compiled condition to match")
+	}
+
+	// Fields modified after Compile are not cached
+	condition.PayloadIssueTitleRegexp = "^Nope"
+	if condition.Matches(event) {
+		t.Errorf("expected modified condition to not match")
+	}
+
+	if err := (&Condition{PayloadIssueBodyRegexp: "("}).Compile(); err == nil {
+		t.Errorf("expected error for invalid regexp")
+	}
+
+	filter := Filter{
+		Conditions: []Condition{{Type: "IssuesEvent"}},
+		Root:       NewOr(&Condition{PayloadProjectNameRegexp: "("}),
+	}
+	if err := filter.Compile(); err == nil {
+		t.Errorf("expected error for invalid regexp in root")
+	}
+	filter.Root = NewOr(&Condition{PayloadIssueTitleRegexp: "Match$"})
+	if err := filter.Compile(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !filter.Matches(event) {
+		t.Errorf("expected compiled filter to match")
+	}
+}
+
+func TestCompiledFilter_profile(t *testing.T) {
+	cf, err := Compile(Filter{
+		Conditions: []Condition{
+			{Type: "IssuesEvent"},
+			{PayloadIssueTitleRegexp: `(?i)will\s+match`},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if profile := cf.Profile(); profile != nil {
+		t.Errorf("expected nil profile when profiling disabled, have: %v", profile)
+	}
+
+	cf.EnableProfiling()
+
+	payload := json.RawMessage(`{"issue":{"title":"This will Match"}}`)
+	event := &github.Event{Type: github.String("IssuesEvent"), RawPayload: &payload}
+
+	// A single evaluation may take less than the clock's resolution, so match
+	// until some time is recorded.
+	var (
+		profile  map[int]time.Duration
+		total    time.Duration
+		deadline = time.Now().Add(5 * time.Second)
+	)
+	for total <= 0 && time.Now().Before(deadline) {
+		for i := 0; i < 100; i++ {
+			if !cf.Matches(event) {
+				t.Fatalf("expected event to match")
+			}
+		}
+		profile, total = cf.Profile(), 0
+		for _, d := range profile {
+			total += d
+		}
+	}
+
+	if len(profile) != 2 {
+		t.Fatalf("have %d profile entries, want 2", len(profile))
+	}
+	for i, d := range profile {
+		if d < 0 {
+			t.Errorf("condition %d has duration %v, want non-negative", i, d)
+		}
+	}
+	if total <= 0 {
+		t.Errorf("have total duration %v, want positive", total)
+	}
+}
+
+func TestCompile_copiesConditions(t *testing.T) {
+	filter := Filter{
+		Conditions: []Condition{{PayloadActionAny: []string{"opened"}}},
+	}
+	cf, err := Compile(filter)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	filter.Conditions[0].PayloadActionAny[0] = "closed"
+
+	payload := json.RawMessage(`{"action":"opened"}`)
+	if !cf.Matches(&github.Event{RawPayload: &payload}) {
+		t.Errorf("expected compiled filter to be unaffected by changes to the original filter")
+	}
+}
+
+func TestCompiledFilter_matchesJSONL(t *testing.T) {
+	cf, err := Compile(Filter{
+		Conditions: []Condition{
+			{Type: "IssuesEvent", PayloadAction: "opened"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	input := `{"id":"1","type":"IssuesEvent","payload":{"action":"opened"}}
+{"id":"2","type":"IssuesEvent","payload":{"action":"closed"}}
+{"id":"3","type":"PushEvent","payload":{}}
+{"id":"4","type":"IssuesEvent","payload":{"action":"opened"}}
+`
+
+	var have []string
+	err = cf.MatchesJSONL(strings.NewReader(input), func(event *github.Event) {
+		have = append(have, event.GetID())
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []string{"1", "4"}; !reflect.DeepEqual(have, want) {
+		t.Errorf("matched events do not match\nhave: %v\nwant: %v", have, want)
+	}
+
+	err = cf.MatchesJSONL(strings.NewReader(`{"id":`), func(*github.Event) {})
+	if err == nil {
+		t.Errorf("expected error for malformed input")
+	}
+}
+
+func BenchmarkCompiledFilter_matchesJSONL(b *testing.B) {
+	cf, err := Compile(Filter{
+		Conditions: []Condition{
+			{Type: "IssuesEvent", PayloadIssueTitleRegexp: `(?i)will\s+match`},
+		},
+	})
+	if err != nil {
+		b.Fatalf("unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	for i := 0; i < 1000; i++ {
+		buf.WriteString(`{"type":"IssuesEvent","payload":{"action":"opened","issue":{"title":"This will Match"}}}` + "\n")
+	}
+	input := buf.Bytes()
+
+	b.SetBytes(int64(len(input)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := cf.MatchesJSONL(bytes.NewReader(input), func(*github.Event) {}); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func BenchmarkCondition_regexp(b *testing.B) {
+	payload := json.RawMessage(`{"issue":{"title":"This will Match","body":"Body"}}`)
+	event := &github.Event{RawPayload: &payload}
+
+	for _, compile := range []bool{false, true} {
+		name := "uncompiled"
+		if compile {
+			name = "compiled"
+		}
+		b.Run(name, func(b *testing.B) {
+			condition := Condition{
+				PayloadIssueTitleRegexp: `(?i)will\s+match`,
+				PayloadIssueBodyRegexp:  `^Body$`,
+			}
+			if compile {
+				if err := condition.Compile(); err != nil {
+					b.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if !condition.Matches(event) {
+					b.Fatal("expected condition to match")
+				}
+			}
+		})
+	}
+}
+
+// simpleCompiledFilter returns a compiled filter without regexp or payload
+// conditions, and an event it matches.
+func simpleCompiledFilter(tb testing.TB) (*CompiledFilter, *github.Event) {
+	cf, err := Compile(Filter{
+		Conditions: []Condition{
+			{Type: "IssuesEvent"},
+			{ComparePublic: true, Public: true},
+			{OrganizationID: 1, RepositoryID: 2},
+			{ActorLogin: "octocat"},
+		},
+		ExcludeTypes: []string{"WatchEvent"},
+	})
+	if err != nil {
+		tb.Fatalf("unexpected error: %v", err)
+	}
+	event := &github.Event{
+		Type:   github.String("IssuesEvent"),
+		Public: github.Bool(true),
+		Org:    &github.Organization{ID: github.Int(1)},
+		Repo:   &github.Repository{ID: github.Int(2)},
+		Actor:  &github.User{Login: github.String("octocat")},
+	}
+	return cf, event
+}
+
+func TestCompiledFilter_matchesAllocs(t *testing.T) {
+	cf, event := simpleCompiledFilter(t)
+	allocs := testing.AllocsPerRun(100, func() {
+		if !cf.Matches(event) {
+			t.Fatal("expected event to match")
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("have %v allocs per Matches, want 0", allocs)
+	}
+}
+
+func BenchmarkCompiledFilter_matches(b *testing.B) {
+	cf, event := simpleCompiledFilter(b)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if !cf.Matches(event) {
+			b.Fatal("expected event to match")
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package ghfilter
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+// dedupKey identifies events which are considered duplicates.
+type dedupKey struct {
+	eventType string
+	repo      string
+	number    int
+}
+
+// DedupFilter wraps a Filter and suppresses repeated matches for the same event
+// type, repository and issue or pull request number within a time window, such as bursts of
+// synchronize events. Events which do not refer to an issue or pull request are
+// never suppressed.
+//
+// At most DefaultCacheCapacity issues and pull requests are remembered, see
+// SetCapacity. A DedupFilter is safe for concurrent use.
+type DedupFilter struct {
+	filter *Filter
+	window time.Duration
+	now    func() time.Time // now returns the current time, replaced in tests
+
+	mu       sync.Mutex
+	capacity int
+	seen     *lruCache // seen is the time.Time of the first match for each dedupKey
+}
+
+// NewDedupFilter returns a DedupFilter which suppresses repeated matches of
+// filter within window of the first match.
+func NewDedupFilter(filter *Filter, window time.Duration) *DedupFilter {
+	return &DedupFilter{
+		filter:   filter,
+		window:   window,
+		now:      time.Now,
+		capacity: DefaultCacheCapacity,
+		seen:     newLRUCache(DefaultCacheCapacity),
+	}
+}
+
+// SetCapacity sets the maximum number of issues and pull requests remembered
+// to n, forgetting all previously matched events. When the capacity is
+// exceeded the issue or pull request whose first match is the oldest is
+// forgotten, so its next match is not suppressed.
+func (d *DedupFilter) SetCapacity(n int) {
+	d.mu.Lock()
+	d.capacity = n
+	d.seen = newLRUCache(n)
+	d.mu.Unlock()
+}
+
+// Matches returns true if event matches the wrapped filter and an event with
+// the same type, repository and issue or pull request number has not matched
+// within the window, else return false.
+func (d *DedupFilter) Matches(event *github.Event) bool {
+	if !d.filter.Matches(event) {
+		return false
+	}
+
+	p := lazyPayload{event: event}
+	payload, ok := p.get("issue", "pull_request", "number")
+	if !ok || payload.number() == 0 {
+		return true
+	}
+	key := dedupKey{eventType: event.GetType(), number: payload.number()}
+	if event.Repo != nil {
+		key.repo = event.Repo.GetName()
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	d.expire(now)
+	if _, ok := d.seen.get(key); ok {
+		return false
+	}
+	d.seen.set(key, now)
+	return true
+}
+
+// expire removes keys whose window has passed, oldest first, d.mu must be
+// held.
+func (d *DedupFilter) expire(now time.Time) {
+	d.seen.removeOldest(func(first interface{}) bool {
+		return now.Sub(first.(time.Time)) >= d.window
+	})
+}
+
+// Reset forgets all previously matched events.
+func (d *DedupFilter) Reset() {
+	d.mu.Lock()
+	d.seen = newLRUCache(d.capacity)
+	d.mu.Unlock()
+}
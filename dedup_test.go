@@ -0,0 +1,109 @@
+package ghfilter
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+func TestDedupFilter(t *testing.T) {
+	var (
+		pr1     = json.RawMessage(`{"action":"synchronize","number":1,"pull_request":{"number":1}}`)
+		pr2     = json.RawMessage(`{"action":"synchronize","number":2,"pull_request":{"number":2}}`)
+		noNum   = json.RawMessage(`{"ref":"refs/heads/master"}`)
+		now     = time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+		pr1Sync = &github.Event{Type: github.String("PullRequestEvent"), RawPayload: &pr1}
+		pr2Sync = &github.Event{Type: github.String("PullRequestEvent"), RawPayload: &pr2}
+		push    = &github.Event{Type: github.String("PushEvent"), RawPayload: &noNum}
+	)
+
+	dedup := NewDedupFilter(&Filter{}, time.Minute)
+	dedup.now = func() time.Time { return now }
+
+	tests := []struct {
+		elapsed time.Duration // elapsed is added to now before matching
+		event   *github.Event
+		want    bool
+	}{
+		{0, pr1Sync, true},
+		{10 * time.Second, pr1Sync, false}, // duplicate within window
+		{0, pr2Sync, true},                 // different number
+		{0, push, true},                    // no number, never suppressed
+		{0, push, true},
+		{50 * time.Second, pr1Sync, true}, // window passed
+		{0, pr2Sync, false},
+	}
+
+	for i, test := range tests {
+		now = now.Add(test.elapsed)
+		if have := dedup.Matches(test.event); have != test.want {
+			t.Errorf("test %d: have: %v, want: %v", i, have, test.want)
+		}
+	}
+
+	dedup.Reset()
+	if !dedup.Matches(pr2Sync) {
+		t.Errorf("expected match after reset")
+	}
+}
+
+func TestDedupFilter_repository(t *testing.T) {
+	var (
+		pr5   = json.RawMessage(`{"action":"synchronize","number":5,"pull_request":{"number":5}}`)
+		now   = time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+		repoA = &github.Event{Type: github.String("PullRequestEvent"), Repo: &github.Repository{Name: github.String("a/a")}, RawPayload: &pr5}
+		repoB = &github.Event{Type: github.String("PullRequestEvent"), Repo: &github.Repository{Name: github.String("b/b")}, RawPayload: &pr5}
+	)
+
+	dedup := NewDedupFilter(&Filter{}, time.Minute)
+	dedup.now = func() time.Time { return now }
+
+	if !dedup.Matches(repoA) {
+		t.Errorf("expected first match for a/a")
+	}
+	if !dedup.Matches(repoB) {
+		t.Errorf("expected same number in a different repository to match")
+	}
+	if dedup.Matches(repoA) {
+		t.Errorf("expected duplicate for a/a to be suppressed")
+	}
+}
+
+func TestDedupFilter_capacity(t *testing.T) {
+	var (
+		pr1 = json.RawMessage(`{"action":"synchronize","number":1,"pull_request":{"number":1}}`)
+		pr2 = json.RawMessage(`{"action":"synchronize","number":2,"pull_request":{"number":2}}`)
+		pr3 = json.RawMessage(`{"action":"synchronize","number":3,"pull_request":{"number":3}}`)
+		now = time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	)
+	event := func(payload json.RawMessage) *github.Event {
+		return &github.Event{Type: github.String("PullRequestEvent"), RawPayload: &payload}
+	}
+
+	dedup := NewDedupFilter(&Filter{}, time.Minute)
+	dedup.now = func() time.Time { return now }
+	dedup.SetCapacity(2)
+
+	tests := []struct {
+		event *github.Event
+		want  bool
+	}{
+		{event(pr1), true},
+		{event(pr2), true},
+		{event(pr1), false},
+		{event(pr3), true}, // pr1 is forgotten
+		{event(pr2), false},
+		{event(pr1), true},
+	}
+
+	for i, test := range tests {
+		if have := dedup.Matches(test.event); have != test.want {
+			t.Errorf("test %d: have: %v, want: %v", i, have, test.want)
+		}
+	}
+	if have := dedup.seen.len(); have != 2 {
+		t.Errorf("have %v keys, want 2", have)
+	}
+}
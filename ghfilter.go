@@ -1,23 +1,455 @@
 package ghfilter
 
 import (
-	"encoding/json"
 	"fmt"
+	"net/url"
+	"path"
+	"reflect"
 	"regexp"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/google/go-github/github"
 )
 
+// Categories maps each category used by Condition's Category field to the
+// event types in that category.
+var Categories = map[string][]string{
+	"issue":   {"IssuesEvent"},
+	"pr":      {"PullRequestEvent", "PullRequestReviewEvent", "PullRequestReviewCommentEvent"},
+	"push":    {"PushEvent"},
+	"comment": {"IssueCommentEvent", "CommitCommentEvent", "PullRequestReviewCommentEvent"},
+	"ci":      {"StatusEvent", "CheckRunEvent", "CheckSuiteEvent", "WorkflowRunEvent", "WorkflowJobEvent"},
+}
+
+// TerminalActions are the payload actions, of any event type, which end an
+// item's life, used by Condition's PayloadActionTerminal. It may be modified to
+// suit, but not concurrently with matching.
+var TerminalActions = []string{"closed", "merged", "deleted"}
+
+// OpeningActions are the payload actions, of any event type, which start an
+// item's life, used by Condition's PayloadActionOpening. It may be modified to
+// suit, but not concurrently with matching.
+var OpeningActions = []string{"opened", "reopened", "created", "published"}
+
+// commentEventTypes are the event types whose body is the payload's comment
+// body, used by Condition's PayloadBodyMentions. Unlike Categories it is not
+// exported, so cannot be modified by callers.
+var commentEventTypes = []string{"IssueCommentEvent", "CommitCommentEvent", "PullRequestReviewCommentEvent"}
+
+// Logic is the operator used to combine a filter's conditions.
+type Logic int
+
+const (
+	// And requires all conditions to match, an empty list of conditions
+	// matches.
+	And Logic = iota
+	// Or requires any condition to match, an empty list of conditions does not
+	// match.
+	Or
+)
+
+// String returns the lowercase name of the operator, such as "and".
+func (l Logic) String() string {
+	switch l {
+	case And:
+		return "and"
+	case Or:
+		return "or"
+	}
+	return fmt.Sprintf("Logic(%d)", int(l))
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (l Logic) MarshalText() ([]byte, error) {
+	if l != And && l != Or {
+		return nil, fmt.Errorf("unknown logic %d", int(l))
+	}
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (l *Logic) UnmarshalText(text []byte) error {
+	switch strings.ToLower(string(text)) {
+	case "and", "":
+		*l = And
+	case "or":
+		*l = Or
+	default:
+		return fmt.Errorf("unknown logic %q", text)
+	}
+	return nil
+}
+
 // Filter is a collection of conditions.
+//
+// Filter and Condition fields are tagged with their JSON names, which do not
+// change, so filters stored as JSON remain compatible with later versions.
 type Filter struct {
-	Conditions []Condition
+	Conditions []Condition `json:"Conditions,omitempty"`
+	// Logic is the operator combining Conditions, the default And requires all
+	// conditions to match, whereas Or requires any condition to match. Funcs must
+	// always all return true, regardless of Logic.
+	Logic Logic `json:"Logic,omitempty"`
+	// Root is an optional tree of conditions for expressions which cannot be
+	// expressed by Conditions, such as "(A AND B) OR (C AND NOT D)". If not nil,
+	// Root must match in addition to Conditions.
+	Root *CompositeNode `json:"Root,omitempty"`
+	// Funcs are custom predicates for checks which cannot be expressed as a
+	// Condition. Each func must return true for the filter to match, they're
+	// evaluated after all Conditions. Funcs cannot be serialised and are
+	// ignored when marshalling a Filter.
+	Funcs []func(*github.Event) bool `json:"-"`
+	// StrictRegexp causes MatchesErr to return an error when a condition
+	// contains an invalid regular expression. When false, an invalid regular
+	// expression causes the condition to not match, which is compatible with
+	// Matches but may hide mistakes in a filter. Strict mode compiles each
+	// regular expression before evaluation, consider using Compile to validate
+	// a filter once instead.
+	StrictRegexp bool `json:"StrictRegexp,omitempty"`
+	// Includes are the names of other filters whose conditions and funcs must also
+	// match. Includes are ignored by Matches, use Resolve to inline them.
+	Includes []string `json:"Includes,omitempty"`
+	// ExcludeTypes are event types which never match the filter. An event whose
+	// Type is any of ExcludeTypes does not match, regardless of Conditions and
+	// Funcs, which are not evaluated.
+	ExcludeTypes []string `json:"ExcludeTypes,omitempty"`
 }
 
-// Matches returns true if event matches all conditions, else return false.
+// Matches returns true if event's type is not excluded and event matches the
+// conditions, combined using Logic, Root and all funcs, else return false.
 func (f *Filter) Matches(event *github.Event) bool {
-	for _, condition := range f.Conditions {
-		if !condition.Matches(event) {
+	if f.excludes(event) {
+		return false
+	}
+	return f.matchesConditions(func(_ int, condition *Condition) bool {
+		return condition.Matches(event)
+	}) && f.matchesRoot(event, time.Now) && f.matchesFuncs(event)
+}
+
+// String returns a human readable description of the filter, such as
+// `If (type is "IssuesEvent") AND (payload action is "opened")`. A filter
+// without any tests returns "Match all events", and a filter which cannot match
+// any event, such as an Or filter without conditions, returns "Match no events".
+// Includes are not described, as they're ignored by Matches.
+func (f *Filter) String() string {
+	var parts []string
+	if len(f.ExcludeTypes) > 0 {
+		parts = append(parts, fmt.Sprintf("type is not any of %s", quoteList(f.ExcludeTypes)))
+	}
+
+	if f.Logic == Or {
+		if len(f.Conditions) == 0 {
+			return "Match no events"
+		}
+		var (
+			descriptions = make([]string, len(f.Conditions))
+			always       bool // always is true if any condition has no tests
+		)
+		for i, condition := range f.Conditions {
+			descriptions[i] = condition.description()
+			if descriptions[i] == "" {
+				always = true
+			}
+		}
+		switch {
+		case always:
+		case len(descriptions) == 1:
+			parts = append(parts, descriptions[0])
+		default:
+			parts = append(parts, "("+strings.Join(descriptions, ") OR (")+")")
+		}
+	} else {
+		for _, condition := range f.Conditions {
+			if description := condition.description(); description != "" {
+				parts = append(parts, description)
+			}
+		}
+	}
+
+	if f.Root != nil {
+		parts = append(parts, f.Root.description())
+	}
+	if len(f.Funcs) > 0 {
+		parts = append(parts, "all custom funcs pass")
+	}
+
+	switch len(parts) {
+	case 0:
+		return "Match all events"
+	case 1:
+		return "If " + parts[0]
+	}
+	return "If (" + strings.Join(parts, ") AND (") + ")"
+}
+
+// matchesRoot returns true if Root is nil or matches event.
+func (f *Filter) matchesRoot(event *github.Event, now func() time.Time) bool {
+	return f.Root == nil || f.Root.matches(event, now)
+}
+
+// matchesConditions combines the result of calling matches with each
+// condition, and its index, using the filter's Logic. Evaluation stops as soon
+// as the result is known.
+func (f *Filter) matchesConditions(matches func(int, *Condition) bool) bool {
+	if f.Logic == Or {
+		for i := range f.Conditions {
+			if matches(i, &f.Conditions[i]) {
+				return true
+			}
+		}
+		return false
+	}
+	for i := range f.Conditions {
+		if !matches(i, &f.Conditions[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchesWithClock is like Matches but uses now as the current time for time
+// based tests, such as a condition's MaxAge.
+func (f *Filter) MatchesWithClock(event *github.Event, now time.Time) bool {
+	if f.excludes(event) {
+		return false
+	}
+	return f.matchesConditions(func(_ int, condition *Condition) bool {
+		return condition.MatchesWithClock(event, now)
+	}) && f.matchesRoot(event, func() time.Time { return now }) && f.matchesFuncs(event)
+}
+
+// HandlesType returns false if no event of eventType could match the filter
+// due to ExcludeTypes or a condition's Type or Category, allowing events to be
+// skipped without evaluating the filter. Returns true if an event of eventType
+// may match, such as when no condition constrains the type.
+func (f *Filter) HandlesType(eventType string) bool {
+	if containsString(f.ExcludeTypes, eventType) {
+		return false
+	}
+	return f.matchesConditions(func(_ int, condition *Condition) bool {
+		return condition.handlesType(eventType)
+	}) && (f.Root == nil || f.Root.handlesType(eventType))
+}
+
+// Compile compiles the regular expressions of the filter's conditions,
+// including those in Root, see Condition.Compile. Returns an error if any
+// regular expression is invalid.
+func (f *Filter) Compile() error {
+	for i := range f.Conditions {
+		if err := f.Conditions[i].Compile(); err != nil {
+			return fmt.Errorf("condition %d: %v", i, err)
+		}
+	}
+	if f.Root != nil {
+		var err error
+		f.Root.conditions(func(condition *Condition) {
+			if err == nil {
+				err = condition.Compile()
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("root: %v", err)
+		}
+	}
+	return nil
+}
+
+// MatchesErr is like Matches but if StrictRegexp is true it returns an error
+// when a condition contains an invalid regular expression. If StrictRegexp is
+// false, the error is always nil.
+func (f *Filter) MatchesErr(event *github.Event) (bool, error) {
+	if f.excludes(event) {
+		return false, nil
+	}
+	var err error
+	matched := f.matchesConditions(func(i int, condition *Condition) bool {
+		if f.StrictRegexp {
+			if rerr := condition.checkRegexps(); rerr != nil {
+				err = fmt.Errorf("condition %d: %v", i, rerr)
+				// Stop evaluation regardless of Logic
+				return f.Logic == Or
+			}
+		}
+		return condition.Matches(event)
+	})
+	if err != nil {
+		return false, err
+	}
+	if matched && f.StrictRegexp && f.Root != nil {
+		if err := f.Root.checkRegexps(); err != nil {
+			return false, fmt.Errorf("root: %v", err)
+		}
+	}
+	return matched && f.matchesRoot(event, time.Now) && f.matchesFuncs(event), nil
+}
+
+// ReasonsAll returns a human readable reason for each condition describing
+// whether it passed or failed for event, such as `PASS: type is "IssuesEvent"`
+// or `FAIL: payload action is "opened" (got payload action "closed")`. Failed
+// reasons include the event's values for the condition's scalar tests, such as
+// type, action and repository name. Unlike Matches, every condition is
+// evaluated. If Root is not nil, a final reason is included for Root as a
+// whole. Funcs are not included.
+func (f *Filter) ReasonsAll(event *github.Event) []string {
+	reasons := make([]string, len(f.Conditions))
+	for i, condition := range f.Conditions {
+		reasons[i] = reason(&condition, event)
+	}
+	if f.Root != nil {
+		reasons = append(reasons, reason(f.Root, event))
+	}
+	return reasons
+}
+
+// ConditionResult is whether a single condition of a filter passed for an
+// event, see Explain.
+type ConditionResult struct {
+	// Index is the condition's index in the filter's Conditions.
+	Index int
+	// Passed is true if the condition matched the event.
+	Passed bool
+	// Description is the condition's String, such as `If type is "IssuesEvent"`.
+	Description string
+}
+
+// Explain returns the result of each condition for event, in the order of
+// Conditions, showing exactly which conditions rejected an event. Unlike
+// Matches, every condition is evaluated. Root, Funcs and ExcludeTypes are not
+// included, so the filter may not match even if every condition passed.
+func (f *Filter) Explain(event *github.Event) []ConditionResult {
+	results := make([]ConditionResult, len(f.Conditions))
+	for i := range f.Conditions {
+		results[i] = ConditionResult{
+			Index:       i,
+			Passed:      f.Conditions[i].Matches(event),
+			Description: f.Conditions[i].String(),
+		}
+	}
+	return results
+}
+
+// reason returns whether node passed or failed for event and its description.
+func reason(node Node, event *github.Event) string {
+	passed := node.Matches(event)
+	if condition, ok := node.(*Condition); ok {
+		return conditionReason(condition, event, passed)
+	}
+	return resultReason(passed, node.description())
+}
+
+// conditionReason returns a reason for a condition which passed or failed for
+// event, including the event's observed values if the condition failed, such
+// as `FAIL: payload action is "opened" (got payload action "closed")`.
+func conditionReason(c *Condition, event *github.Event, passed bool) string {
+	reason := resultReason(passed, c.description())
+	if observed := c.observed(event); !passed && len(observed) > 0 {
+		reason += " (got " + strings.Join(observed, ", ") + ")"
+	}
+	return reason
+}
+
+// resultReason returns a reason, such as `PASS: type is "IssuesEvent"`. An
+// empty description, such as for a condition without any tests, is described
+// as "(no tests)".
+func resultReason(passed bool, description string) string {
+	if description == "" {
+		description = "(no tests)"
+	}
+	if passed {
+		return "PASS: " + description
+	}
+	return "FAIL: " + description
+}
+
+// observed returns the event's values compared by the condition's scalar
+// tests, and its issue labels, described as in the condition's description, such as
+// `payload action "closed"`. Values which the event does not have are not
+// included.
+func (c *Condition) observed(event *github.Event) []string {
+	var values []string
+	str := func(tested bool, name, value string) {
+		if tested && value != "" {
+			values = append(values, fmt.Sprintf("%s %q", name, value))
+		}
+	}
+	num := func(tested bool, name string, value int) {
+		if tested && value != 0 {
+			values = append(values, fmt.Sprintf("%s %d", name, value))
+		}
+	}
+
+	str(c.Type != "" || len(c.Types) > 0, "type", event.GetType())
+	if event.Org != nil {
+		num(c.OrganizationID != 0, "organization ID", event.Org.GetID())
+	}
+	if event.Repo != nil {
+		num(c.RepositoryID != 0, "repository ID", event.Repo.GetID())
+		str(c.RepositoryName != "" || c.RepositoryNameGlob != "", "repository name", event.Repo.GetName())
+	}
+	if event.Actor != nil {
+		str(c.ActorLogin != "", "actor login", event.Actor.GetLogin())
+		num(c.ActorID != 0, "actor ID", event.Actor.GetID())
+	}
+
+	p := lazyPayload{event: event}
+	payload, ok := p.get()
+	if !ok {
+		return values
+	}
+	str(c.PayloadAction != "" || len(c.PayloadActionAny) > 0 || c.PayloadPullRequestAction != "", "payload action", payload.Action)
+	str(c.PayloadIssueMilestoneTitle != "", "payload issue milestone title", payload.Issue.Milestone.Title)
+	str(c.PayloadIssueState != "", "payload issue state", payload.Issue.State)
+	str(c.PayloadReactionContent != "", "payload reaction content", payload.Reaction.Content)
+	str(c.PayloadMembershipUserLogin != "", "payload membership user login", payload.Membership.User.Login)
+	str(c.PayloadLabelName != "", "payload label name", payload.Label.Name)
+	str(c.PayloadDeploymentStatusState != "", "payload deployment status state", payload.DeploymentStatus.State)
+	str(c.PayloadWorkflowJobConclusion != "", "payload workflow job conclusion", payload.WorkflowJob.Conclusion)
+	str(c.PayloadRef != "" || c.PayloadRefRegexp != "", "payload ref", payload.Ref)
+	str(c.PayloadSecurityAdvisorySeverity != "", "payload security advisory severity", payload.SecurityAdvisory.Severity)
+	num(c.PayloadSenderID != 0, "payload sender ID", payload.Sender.ID)
+	if c.PayloadIssueLabel != "" || len(c.PayloadIssueLabels) > 0 {
+		if labels, ok := payload.issueLabels(); ok {
+			values = append(values, "payload issue labels "+quoteList(labels))
+		}
+	}
+	if payload.PullRequest != nil {
+		if payload.PullRequest.Milestone != nil {
+			str(c.PayloadPullRequestMilestoneTitle != "", "payload pull request milestone title", payload.PullRequest.Milestone.Title)
+		}
+		str(c.PayloadPullRequestBaseRef != "", "payload pull request base ref", payload.PullRequest.Base.Ref)
+	}
+	str(c.PayloadPullRequestRequestedReviewer != "", "payload pull request requested reviewer", payload.RequestedReviewer.Login)
+	return values
+}
+
+// RejectionHistogram returns, keyed by condition index, the number of events
+// each condition was the first to reject, showing which conditions do the most
+// filtering. Conditions which did not reject any events are not included.
+// Conditions are evaluated as if Logic is And, Root is not evaluated.
+func (f *Filter) RejectionHistogram(events []*github.Event) map[int]int {
+	histogram := make(map[int]int)
+	for _, event := range events {
+		for i, condition := range f.Conditions {
+			if !condition.Matches(event) {
+				histogram[i]++
+				break
+			}
+		}
+	}
+	return histogram
+}
+
+// excludes returns true if the event's type is any of ExcludeTypes.
+func (f *Filter) excludes(event *github.Event) bool {
+	return containsString(f.ExcludeTypes, event.GetType())
+}
+
+// matchesFuncs returns true if all of the filter's Funcs return true.
+func (f *Filter) matchesFuncs(event *github.Event) bool {
+	for _, fn := range f.Funcs {
+		if !fn(event) {
 			return false
 		}
 	}
@@ -32,58 +464,486 @@ type Condition struct {
 	// For example, if Nagate is true and PayloadAction a non zero value, if the
 	// event does not have a payload with an action key, the match will continue
 	// to be false.
-	Negate bool
+	Negate bool `json:"Negate,omitempty"`
 	// Type compares the Event's Type field. An empty Type will skip the check.
-	Type string
+	Type string `json:"Type,omitempty"`
 	// PayloadAction compares the event's Action field in its payload. If not empty
 	// the event must have a non-nil payload, must have an string action field. An
-	// empty PayloadAction will skip the check. Comparison is case insensitive.
-	PayloadAction string
+	// empty PayloadAction will skip the check. Comparison is case insensitive,
+	// unless CaseSensitive is set.
+	PayloadAction string `json:"PayloadAction,omitempty"`
 	// PayloadIssueLabel compares the event's issue labels array. If not empty
 	// the payload must have a non-nil payload, issue and labels field. If empty the
-	// fields are not checked. Comparison is case insensitive.
-	PayloadIssueLabel string
+	// fields are not checked. Comparison is case insensitive, unless
+	// CaseSensitive is set.
+	PayloadIssueLabel string `json:"PayloadIssueLabel,omitempty"`
 	// PayloadIssueMilestoneTitle compares the event's issue milestone's title. If not
 	// empty the payload must have a non-nil payload, issue and milestone field. If
-	// empty the fields are not checked. Comparison is case insensitive.
-	PayloadIssueMilestoneTitle string
+	// empty the fields are not checked. Comparison is case insensitive, unless
+	// CaseSensitive is set.
+	PayloadIssueMilestoneTitle string `json:"PayloadIssueMilestoneTitle,omitempty"`
 	// PayloadIssueTitleRegexp compares the event's issue title against regexp. If not
 	// empty the payload must have a non-nil payload, issue and title field. If
 	// empty the fields are not checked. See https://golang.org/pkg/regexp for syntax.
-	PayloadIssueTitleRegexp string
+	PayloadIssueTitleRegexp string `json:"PayloadIssueTitleRegexp,omitempty"`
 	// PayloadIssueBodyRegexp compares the event's issue body against regexp. If not
 	// empty the payload must have a non-nil payload, issue and body field. If
 	// empty the fields are not checked. See https://golang.org/pkg/regexp for syntax.
-	PayloadIssueBodyRegexp string
+	PayloadIssueBodyRegexp string `json:"PayloadIssueBodyRegexp,omitempty"`
 	// ComparePublic enables comparing of the event's public field with the condition's
 	// Public value. Setting to false will skip checking the Public field.
-	ComparePublic bool
+	ComparePublic bool `json:"ComparePublic,omitempty"`
 	// Public compares the event's Public field. ComparePublic must be set to true to
 	// compare the Public field.
-	Public bool
+	Public bool `json:"Public,omitempty"`
 	// OrganizationID compares the event's Organizaton's ID field. The event must have
 	// a non-nil Organization. A zero value will skip the check.
-	OrganizationID int
+	OrganizationID int `json:"OrganizationID,omitempty"`
 	// RepositoryID compares the event's Repository's ID field. The event must have
 	// a non-nil Repository. A zero value will skip the check.
-	RepositoryID int
+	RepositoryID int `json:"RepositoryID,omitempty"`
+	// PayloadReactionContent compares the event's reaction content, such as "+1"
+	// or "rocket". If not empty the payload must have a non-nil payload, reaction
+	// and content field. If empty the fields are not checked. Comparison is exact.
+	PayloadReactionContent string `json:"PayloadReactionContent,omitempty"`
+	// PayloadCommitCommentPathRegexp compares the event's commit comment path
+	// against regexp. If not empty the payload must have a non-nil payload, comment
+	// and path field. If empty the fields are not checked. See
+	// https://golang.org/pkg/regexp for syntax.
+	PayloadCommitCommentPathRegexp string `json:"PayloadCommitCommentPathRegexp,omitempty"`
+	// PayloadIssueAuthorAssociations compares the event's issue author association,
+	// such as "MEMBER" or "FIRST_TIMER", matching if it's any of the listed values.
+	// If not empty the payload must have a non-nil payload, issue and
+	// author_association field. If empty the fields are not checked. Comparison is
+	// case insensitive.
+	PayloadIssueAuthorAssociations []string `json:"PayloadIssueAuthorAssociations,omitempty"`
+	// PayloadSizeMin compares the size in bytes of the event's raw payload, which
+	// must be at least PayloadSizeMin. If not zero the event must have a non-nil
+	// payload. A zero value will skip the check.
+	PayloadSizeMin int `json:"PayloadSizeMin,omitempty"`
+	// PayloadSizeMax compares the size in bytes of the event's raw payload, which
+	// must be at most PayloadSizeMax. If not zero the event must have a non-nil
+	// payload. A zero value will skip the check.
+	PayloadSizeMax int `json:"PayloadSizeMax,omitempty"`
+	// PayloadMembershipUserLogin compares the event's membership user login, as
+	// found in organization membership events. If not empty the payload must have
+	// a non-nil payload, membership, user and login field. If empty the fields are
+	// not checked. Comparison is case insensitive.
+	PayloadMembershipUserLogin string `json:"PayloadMembershipUserLogin,omitempty"`
+	// PayloadPullRequestMilestoneTitle compares the event's pull request milestone's
+	// title. If not empty the payload must have a non-nil payload, pull_request and
+	// milestone field. If empty the fields are not checked. Comparison is case
+	// insensitive.
+	PayloadPullRequestMilestoneTitle string `json:"PayloadPullRequestMilestoneTitle,omitempty"`
+	// PayloadActionAny compares the event's Action field in its payload, matching
+	// if it's any of the listed actions. If not empty the event must have a non-nil
+	// payload. An empty PayloadActionAny will skip the check. Comparison is case
+	// insensitive.
+	//
+	// When Negate is true, the condition means the action is none of the listed
+	// actions, so an event whose action is in the list does not match and an event
+	// whose action is not in the list does.
+	PayloadActionAny []string `json:"PayloadActionAny,omitempty"`
+	// PayloadRepositoryTopicsAny compares the event's repository topics, matching
+	// if any of the listed topics are present. If not empty the payload must have
+	// a non-nil payload, repository and topics field. If empty the fields are not
+	// checked. Comparison is case insensitive.
+	PayloadRepositoryTopicsAny []string `json:"PayloadRepositoryTopicsAny,omitempty"`
+	// Category compares the event's Type field against the event types for the
+	// category in Categories, such as "comment". An unknown category does not
+	// match any event type. An empty Category will skip the check.
+	Category string `json:"Category,omitempty"`
+	// PayloadHeadCommitAfter compares the event's head commit timestamp, as found
+	// in push events, which must be after PayloadHeadCommitAfter. If not zero the
+	// payload must have a non-nil payload, head_commit and RFC 3339 timestamp
+	// field. A zero value will skip the check.
+	PayloadHeadCommitAfter time.Time `json:"PayloadHeadCommitAfter,omitempty"`
+	// PayloadHeadCommitBefore compares the event's head commit timestamp, as found
+	// in push events, which must be before PayloadHeadCommitBefore. If not zero the
+	// payload must have a non-nil payload, head_commit and RFC 3339 timestamp
+	// field. A zero value will skip the check.
+	PayloadHeadCommitBefore time.Time `json:"PayloadHeadCommitBefore,omitempty"`
+	// ComparePushCreated enables comparing of the event's push created field with
+	// the condition's PushCreated value. If true the event must have a non-nil
+	// payload, a missing created field is treated as false. Setting to false will
+	// skip checking the created field.
+	ComparePushCreated bool `json:"ComparePushCreated,omitempty"`
+	// PushCreated compares the event's push created field, which is true when the
+	// push created a branch or tag. ComparePushCreated must be set to true to
+	// compare the created field.
+	PushCreated bool `json:"PushCreated,omitempty"`
+	// ComparePushDeleted enables comparing of the event's push deleted field with
+	// the condition's PushDeleted value. If true the event must have a non-nil
+	// payload, a missing deleted field is treated as false. Setting to false will
+	// skip checking the deleted field.
+	ComparePushDeleted bool `json:"ComparePushDeleted,omitempty"`
+	// PushDeleted compares the event's push deleted field, which is true when the
+	// push deleted a branch or tag. ComparePushDeleted must be set to true to
+	// compare the deleted field.
+	PushDeleted bool `json:"PushDeleted,omitempty"`
+	// PayloadPushDistinctAuthorsMin compares the number of distinct commit author
+	// emails in the event's push commits, which must be at least
+	// PayloadPushDistinctAuthorsMin. If not zero the event must have a non-nil
+	// payload. A zero value will skip the check.
+	PayloadPushDistinctAuthorsMin int `json:"PayloadPushDistinctAuthorsMin,omitempty"`
+	// PayloadBodyMentions checks whether the event's body mentions a user, such as
+	// "@octocat". For comment events (IssueCommentEvent, CommitCommentEvent and
+	// PullRequestReviewCommentEvent) the payload's comment body is inspected, for
+	// all other events the payload's issue body is inspected. If not empty the
+	// event must have a non-nil payload. If empty the fields are not checked.
+	// Comparison is case insensitive and the leading @ is optional.
+	PayloadBodyMentions string `json:"PayloadBodyMentions,omitempty"`
+	// PayloadSenderID compares the event's sender's ID field in its payload, which
+	// unlike the sender's login, does not change if the user is renamed. If not
+	// zero the event must have a non-nil payload. A zero value will skip the check.
+	PayloadSenderID int `json:"PayloadSenderID,omitempty"`
+	// PayloadPullRequestIsCrossRepo checks whether the event's pull request head
+	// repository differs from its base repository, such as a pull request from a
+	// fork. If true the payload must have a non-nil payload, pull_request, base,
+	// repo and full_name field, a missing head repository, such as a deleted fork,
+	// is considered a different repository. If false the fields are not checked.
+	PayloadPullRequestIsCrossRepo bool `json:"PayloadPullRequestIsCrossRepo,omitempty"`
+	// CompareRepositoryArchived enables comparing of the event's repository
+	// archived field with the condition's RepositoryArchived value. If true the
+	// event must have a non-nil payload, a missing archived field is treated as
+	// false. Setting to false will skip checking the archived field.
+	CompareRepositoryArchived bool `json:"CompareRepositoryArchived,omitempty"`
+	// RepositoryArchived compares the event's repository archived field.
+	// CompareRepositoryArchived must be set to true to compare the archived field.
+	RepositoryArchived bool `json:"RepositoryArchived,omitempty"`
+	// PayloadProjectNameRegexp compares the event's project name against regexp,
+	// as found in project events. If not empty the payload must have a non-nil
+	// payload, project and name field. If empty the fields are not checked. See
+	// https://golang.org/pkg/regexp for syntax.
+	PayloadProjectNameRegexp string `json:"PayloadProjectNameRegexp,omitempty"`
+	// PayloadPushTouchesPath checks whether any of the files added, modified or
+	// removed by the event's push commits match regexp. If not empty the event must
+	// have a non-nil payload. If empty the fields are not checked. See
+	// https://golang.org/pkg/regexp for syntax.
+	PayloadPushTouchesPath string `json:"PayloadPushTouchesPath,omitempty"`
+	// PayloadLabelName compares the event's label name, which is the label added
+	// or removed in an issue or pull request's labeled and unlabeled actions,
+	// rather than the issue's labels. If not empty the payload must have a non-nil
+	// payload, label and name field. If empty the fields are not checked.
+	// Comparison is case insensitive.
+	PayloadLabelName string `json:"PayloadLabelName,omitempty"`
+	// MaxAge compares the event's CreatedAt field, which must be no older than
+	// MaxAge. The event must have a non-zero CreatedAt. A zero value will skip the
+	// check.
+	MaxAge time.Duration `json:"MaxAge,omitempty"`
+	// PayloadDeploymentStatusState compares the event's deployment status state,
+	// such as "success" or "failure". If not empty the payload must have a non-nil
+	// payload, deployment_status and state field. If empty the fields are not
+	// checked. Comparison is case insensitive.
+	PayloadDeploymentStatusState string `json:"PayloadDeploymentStatusState,omitempty"`
+	// PayloadCommentCommand checks whether the event's comment body begins with a
+	// command, such as "/deploy", ignoring leading whitespace. The command must be
+	// followed by whitespace or the end of the body. If not empty the payload must
+	// have a non-nil payload, comment and body field. If empty the fields are not
+	// checked. Comparison is case insensitive.
+	PayloadCommentCommand string `json:"PayloadCommentCommand,omitempty"`
+	// PayloadIssueMilestoneDueAfter compares the event's issue milestone's due
+	// date, which must be after PayloadIssueMilestoneDueAfter. If not zero the
+	// payload must have a non-nil payload, issue, milestone and RFC 3339 due_on
+	// field. A zero value will skip the check.
+	PayloadIssueMilestoneDueAfter time.Time `json:"PayloadIssueMilestoneDueAfter,omitempty"`
+	// PayloadIssueMilestoneDueBefore compares the event's issue milestone's due
+	// date, which must be before PayloadIssueMilestoneDueBefore. If not zero the
+	// payload must have a non-nil payload, issue, milestone and RFC 3339 due_on
+	// field. A zero value will skip the check.
+	PayloadIssueMilestoneDueBefore time.Time `json:"PayloadIssueMilestoneDueBefore,omitempty"`
+	// ActorAllowed is called with the event's Actor's login, which is allowed if it
+	// returns true, such as checking a list of trusted organisation members. The
+	// event must have a non-nil Actor. A nil ActorAllowed will skip the check.
+	// ActorAllowed cannot be serialised and is ignored when marshalling a
+	// Condition.
+	ActorAllowed func(login string) bool `json:"-"`
+	// PayloadPullRequestCommitsMin compares the number of commits in the event's
+	// pull request, which must be at least PayloadPullRequestCommitsMin. If not
+	// zero the event must have a non-nil payload. A zero value will skip the check.
+	PayloadPullRequestCommitsMin int `json:"PayloadPullRequestCommitsMin,omitempty"`
+	// PayloadPullRequestCommitsMax compares the number of commits in the event's
+	// pull request, which must be at most PayloadPullRequestCommitsMax. If not
+	// zero the event must have a non-nil payload. A zero value will skip the check.
+	PayloadPullRequestCommitsMax int `json:"PayloadPullRequestCommitsMax,omitempty"`
+	// PayloadIssueBodyHasTaskList checks whether the event's issue body contains a
+	// markdown task list item, such as "- [ ] task" or "- [x] task". If true the
+	// payload must have a non-nil payload. If false the fields are not checked.
+	PayloadIssueBodyHasTaskList bool `json:"PayloadIssueBodyHasTaskList,omitempty"`
+	// PayloadPushFilesChangedMin compares the number of distinct files added,
+	// modified or removed by the event's push commits, which must be at least
+	// PayloadPushFilesChangedMin. If not zero the event must have a non-nil
+	// payload. A zero value will skip the check.
+	PayloadPushFilesChangedMin int `json:"PayloadPushFilesChangedMin,omitempty"`
+	// PayloadPushFilesChangedMax compares the number of distinct files added,
+	// modified or removed by the event's push commits, which must be at most
+	// PayloadPushFilesChangedMax. If not zero the event must have a non-nil
+	// payload. A zero value will skip the check.
+	PayloadPushFilesChangedMax int `json:"PayloadPushFilesChangedMax,omitempty"`
+	// PayloadRepositoryStarsMin compares the event's repository stargazers count,
+	// which must be at least PayloadRepositoryStarsMin. If not zero the payload
+	// must have a non-nil payload, repository and stargazers_count field. A zero
+	// value will skip the check.
+	PayloadRepositoryStarsMin int `json:"PayloadRepositoryStarsMin,omitempty"`
+	// CompareIssueLocked enables comparing of the event's issue locked field with
+	// the condition's IssueLocked value. If true the event must have a non-nil
+	// payload, a missing locked field is treated as false. Setting to false will
+	// skip checking the locked field.
+	CompareIssueLocked bool `json:"CompareIssueLocked,omitempty"`
+	// IssueLocked compares the event's issue locked field. CompareIssueLocked must
+	// be set to true to compare the locked field.
+	IssueLocked bool `json:"IssueLocked,omitempty"`
+	// PayloadCommentWasEdited checks whether the event's payload action is
+	// "edited" and it has a non-nil changes and body field, such as when an
+	// IssueCommentEvent's comment body is edited. If true the event must have a
+	// non-nil payload. If false the fields are not checked.
+	PayloadCommentWasEdited bool `json:"PayloadCommentWasEdited,omitempty"`
+	// ActorLogin compares the event's Actor's Login field. The event must have a
+	// non-nil Actor. An empty value will skip the check. Comparison is case
+	// insensitive.
+	ActorLogin string `json:"ActorLogin,omitempty"`
+	// ActorID compares the event's Actor's ID field, which unlike ActorLogin does
+	// not change when a user is renamed. The event must have a non-nil Actor. A
+	// zero value will skip the check.
+	ActorID int `json:"ActorID,omitempty"`
+	// PayloadPushIsTag checks whether the event's push ref is a tag, that is it
+	// begins with "refs/tags/". If true the event must have a non-nil payload.
+	// If false the ref is not checked.
+	PayloadPushIsTag bool `json:"PayloadPushIsTag,omitempty"`
+	// BusinessHours compares the hour of the event's CreatedAt field, which must
+	// be within BusinessHours. The event must have a non-zero CreatedAt. A nil
+	// value will skip the check.
+	BusinessHours *BusinessHours `json:"BusinessHours,omitempty"`
+	// RepositoryName compares the event's Repository's Name field, which for
+	// events is the repository's full name, such as "owner/repo". The event must
+	// have a non-nil Repository. An empty value will skip the check. Comparison
+	// is case insensitive.
+	RepositoryName string `json:"RepositoryName,omitempty"`
+	// PayloadWorkflowJobConclusion compares the event's workflow job conclusion,
+	// such as "success" or "failure". If not empty the payload must have a
+	// non-nil payload, workflow_job and conclusion field. If empty the fields are
+	// not checked. Comparison is case insensitive.
+	PayloadWorkflowJobConclusion string `json:"PayloadWorkflowJobConclusion,omitempty"`
+	// RepositoryNameGlob compares the event's Repository's Name field, such as
+	// "owner/repo", with a glob pattern using the syntax of path.Match, such as
+	// "owner/*". The event must have a non-nil Repository. An empty value will
+	// skip the check. An invalid pattern never matches. Comparison is case
+	// insensitive.
+	RepositoryNameGlob string `json:"RepositoryNameGlob,omitempty"`
+	// PayloadWorkflowJobLabelsAny compares the event's workflow job runner
+	// labels, such as "self-hosted", matching if any of the listed labels are
+	// present. If not empty the payload must have a non-nil payload, workflow_job
+	// and labels field. If empty the fields are not checked. Comparison is case
+	// insensitive.
+	PayloadWorkflowJobLabelsAny []string `json:"PayloadWorkflowJobLabelsAny,omitempty"`
+	// PayloadActionTerminal checks whether the event's payload action is any of
+	// TerminalActions, such as "closed". If true the event must have a non-nil
+	// payload. If false the action is not checked. Comparison is case
+	// insensitive.
+	PayloadActionTerminal bool `json:"PayloadActionTerminal,omitempty"`
+	// PayloadActionOpening checks whether the event's payload action is any of
+	// OpeningActions, such as "opened". If true the event must have a non-nil
+	// payload. If false the action is not checked. Comparison is case
+	// insensitive.
+	PayloadActionOpening bool `json:"PayloadActionOpening,omitempty"`
+	// PayloadCommentLengthMin compares the number of characters, not bytes, in
+	// the event's comment body, which must be at least PayloadCommentLengthMin.
+	// If not zero the event must have a non-nil payload. A zero value will skip
+	// the check.
+	PayloadCommentLengthMin int `json:"PayloadCommentLengthMin,omitempty"`
+	// PayloadCommentLengthMax compares the number of characters, not bytes, in
+	// the event's comment body, which must be at most PayloadCommentLengthMax.
+	// If not zero the event must have a non-nil payload. A zero value will skip
+	// the check.
+	PayloadCommentLengthMax int `json:"PayloadCommentLengthMax,omitempty"`
+	// PayloadURLHostRegexp compares the host of the event's repository HTML URL,
+	// such as "github.com" or a GitHub Enterprise host, with a regular
+	// expression. If not empty the payload must have a non-nil payload,
+	// repository and html_url field. If empty the fields are not checked.
+	PayloadURLHostRegexp string `json:"PayloadURLHostRegexp,omitempty"`
+	// CaseSensitive causes PayloadAction, PayloadIssueLabel, PayloadIssueLabels,
+	// PayloadIssueMilestoneTitle and PayloadPullRequestBaseRef to be compared
+	// case sensitively, such as to distinguish a "WIP" label from "wip". Other
+	// fields are unaffected.
+	CaseSensitive bool `json:"CaseSensitive,omitempty"`
+	// PayloadPullRequestAction compares the event's payload action, such as
+	// "closed", for payloads with a pull request. If not empty the event must
+	// have a non-nil payload, action and pull_request field. If empty the fields
+	// are not checked. Comparison is case insensitive.
+	PayloadPullRequestAction string `json:"PayloadPullRequestAction,omitempty"`
+	// PayloadPullRequestMerged compares the event's pull request merged field,
+	// such as to distinguish a merged pull request from one closed without
+	// merging. If not nil the event must have a non-nil payload, pull_request
+	// and merged field. A nil value will skip the check.
+	PayloadPullRequestMerged *bool `json:"PayloadPullRequestMerged,omitempty"`
+	// PayloadPullRequestBaseRef compares the event's pull request base branch,
+	// such as "main". If not empty the event must have a non-nil payload,
+	// pull_request and base ref field. If empty the fields are not checked.
+	// Comparison is case insensitive, unless CaseSensitive is set.
+	PayloadPullRequestBaseRef string `json:"PayloadPullRequestBaseRef,omitempty"`
+	// PayloadIssueTransferred checks whether the event's payload action is
+	// "transferred" and it has a non-nil changes and new_issue or new_repository
+	// field, such as when an issue is moved to another repository. If true the
+	// event must have a non-nil payload. If false the fields are not checked.
+	PayloadIssueTransferred bool `json:"PayloadIssueTransferred,omitempty"`
+	// PayloadJSONPathBool compares a boolean field at a path in the event's
+	// payload, such as "pull_request.draft", for fields without a specific
+	// condition. A missing field is false. If not nil the event must have a
+	// non-nil payload. A nil value will skip the check.
+	PayloadJSONPathBool *JSONPathBool `json:"PayloadJSONPathBool,omitempty"`
+	// PayloadPullRequestHeadRefRegexp compares the event's pull request head
+	// branch, such as "release/1.2", with a regular expression. If not empty the
+	// event must have a non-nil payload, pull_request and head ref field. If empty
+	// the fields are not checked.
+	PayloadPullRequestHeadRefRegexp string `json:"PayloadPullRequestHeadRefRegexp,omitempty"`
+	// PayloadRef compares the event's payload ref, such as "refs/heads/main" for
+	// a PushEvent. If not empty the event must have a non-nil payload and ref
+	// field. If empty the fields are not checked. Comparison is case sensitive, as
+	// git refs are case sensitive.
+	PayloadRef string `json:"PayloadRef,omitempty"`
+	// PayloadRefRegexp compares the event's payload ref, such as
+	// "refs/heads/main", with a regular expression. If not empty the event must
+	// have a non-nil payload and ref field. If empty the fields are not checked.
+	PayloadRefRegexp string `json:"PayloadRefRegexp,omitempty"`
+	// PayloadCommitsCountMin compares the number of commits in the event's push,
+	// which must be at least PayloadCommitsCountMin. If not zero the event must
+	// have a non-nil payload and commits field. A zero value will skip the check.
+	PayloadCommitsCountMin int `json:"PayloadCommitsCountMin,omitempty"`
+	// PayloadCommitsCountMax compares the number of commits in the event's push,
+	// which must be at most PayloadCommitsCountMax. If not zero the event must
+	// have a non-nil payload and commits field. A zero value will skip the check.
+	PayloadCommitsCountMax int `json:"PayloadCommitsCountMax,omitempty"`
+	// PayloadCommitMessageRegexp compares the messages of the event's push
+	// commits with a regular expression, such as "(?i)hotfix", and matches if
+	// any commit's message matches. If not empty the event must have a non-nil
+	// payload and commits field. If empty the fields are not checked.
+	PayloadCommitMessageRegexp string `json:"PayloadCommitMessageRegexp,omitempty"`
+	// PayloadCommentBodyRegexp compares the event's comment body, such as of an
+	// IssueCommentEvent or PullRequestReviewCommentEvent, with a regular
+	// expression, such as "^/deploy". If not empty the event must have a non-nil
+	// payload and comment field. If empty the fields are not checked.
+	PayloadCommentBodyRegexp string `json:"PayloadCommentBodyRegexp,omitempty"`
+	// PayloadSenderIsRepoOwner checks whether the event's payload sender login is
+	// the repository owner login, such as to distinguish actions by a personal
+	// repository's owner. If true the event must have a non-nil payload, sender
+	// login and repository owner login field. If false the fields are not checked.
+	// Comparison is case insensitive.
+	PayloadSenderIsRepoOwner bool `json:"PayloadSenderIsRepoOwner,omitempty"`
+	// PayloadPackageEcosystem compares the event's package type, such as "npm"
+	// or "container" for a PackageEvent, using the payload's package_type field,
+	// or ecosystem if package_type is not present. If not empty the event must
+	// have a non-nil payload and package field. If empty the fields are not
+	// checked. Comparison is case insensitive.
+	PayloadPackageEcosystem string `json:"PayloadPackageEcosystem,omitempty"`
+	// PayloadIssueNumber compares the event's issue number, or pull request
+	// number if the payload does not have an issue, such as to scope a filter to
+	// a single issue. If not zero the event must have a non-nil payload and issue
+	// or pull_request field. A zero value will skip the check.
+	PayloadIssueNumber int `json:"PayloadIssueNumber,omitempty"`
+	// PayloadIssueState compares the event's issue state, such as "open" or
+	// "closed". If not empty the event must have a non-nil payload, issue and
+	// state field. If empty the fields are not checked. Comparison is case
+	// insensitive.
+	PayloadIssueState string `json:"PayloadIssueState,omitempty"`
+	// PayloadSampleRate matches a deterministic sample of events, such as 0.1
+	// for 10% of events, by hashing the event's ID. Unlike a SamplingFilter, the
+	// same event is always included or excluded, even by different processes.
+	// The event must have a non-nil ID, unless PayloadSampleRate is 1 or more,
+	// which matches all events. A zero value will skip the check.
+	PayloadSampleRate float64 `json:"PayloadSampleRate,omitempty"`
+	// PayloadRepositorySizeMin compares the event's repository size in
+	// kilobytes, which must be at least PayloadRepositorySizeMin. If not zero the
+	// event must have a non-nil payload, repository and size field. A zero value
+	// will skip the check.
+	PayloadRepositorySizeMin int `json:"PayloadRepositorySizeMin,omitempty"`
+	// PayloadRepositorySizeMax compares the event's repository size in
+	// kilobytes, which must be at most PayloadRepositorySizeMax. If not zero the
+	// event must have a non-nil payload, repository and size field. A zero value
+	// will skip the check.
+	PayloadRepositorySizeMax int `json:"PayloadRepositorySizeMax,omitempty"`
+	// PayloadIssueLabels compares the event's issue labels array, matching if
+	// all of the listed labels are present, unlike PayloadIssueLabel which
+	// requires a single label. If not empty the event must have a non-nil payload,
+	// issue and labels field. If empty the fields are not checked. Comparison is
+	// case insensitive, unless CaseSensitive is set.
+	PayloadIssueLabels []string `json:"PayloadIssueLabels,omitempty"`
+	// Types compares the Event's Type field, matching if it's any of the listed
+	// types, such as to match both issues and pull requests without a condition
+	// for each. If Type is also set, the event's type may be Type or any of Types.
+	// An empty Types will skip the check.
+	Types []string `json:"Types,omitempty"`
+	// PayloadSecurityAdvisorySeverity compares the event's security advisory
+	// severity, such as "low", "moderate", "high" or "critical" for a
+	// SecurityAdvisoryEvent. If not empty the event must have a non-nil payload,
+	// security_advisory and severity field. If empty the fields are not checked.
+	// Comparison is case insensitive.
+	PayloadSecurityAdvisorySeverity string `json:"PayloadSecurityAdvisorySeverity,omitempty"`
+	// PayloadHasNoAction checks whether the event's payload does not have an
+	// action, such as a PushEvent, or the event does not have a payload. Unlike
+	// other payload tests, an event without a payload passes. An empty action is
+	// treated as no action. If false the fields are not checked.
+	PayloadHasNoAction bool `json:"PayloadHasNoAction,omitempty"`
+	// CreatedAfter compares the event's CreatedAt field, which must be after
+	// CreatedAfter. If not zero the event must have a non-zero CreatedAt. A zero
+	// value will skip the check.
+	CreatedAfter time.Time `json:"CreatedAfter,omitempty"`
+	// CreatedBefore compares the event's CreatedAt field, which must be before
+	// CreatedBefore. If not zero the event must have a non-zero CreatedAt. A zero
+	// value will skip the check.
+	CreatedBefore time.Time `json:"CreatedBefore,omitempty"`
+	// PayloadPullRequestRequestedReviewer compares the login of the user whose
+	// review was requested, or whose review request was removed, such as in a
+	// PullRequestEvent with the action "review_request_removed". If not empty
+	// the event must have a non-nil payload, requested_reviewer and login field.
+	// If empty the fields are not checked. Comparison is case insensitive.
+	PayloadPullRequestRequestedReviewer string `json:"PayloadPullRequestRequestedReviewer,omitempty"`
+
+	// compiled caches regular expressions compiled by Compile, keyed by their
+	// expression. It is not modified after Compile returns.
+	compiled map[string]*regexp.Regexp
 }
 
+// taskListRegexp matches a markdown task list item.
+var taskListRegexp = regexp.MustCompile(`(?m)^\s*[-*+] \[[ xX]\]`)
+
 func (c Condition) String() string {
+	return fmt.Sprintf("If %v", c.description())
+}
+
+// description returns each of the condition's tests joined with AND, such as
+// `type is "IssuesEvent" AND payload action is "opened"`.
+func (c Condition) description() string {
 	var (
 		conditions []string
 		is         = "is"
 		matches    = "matches"
 		contains   = "contains"
+		contain    = "contain"
+		not        = ""
+		has        = "has"
+		mentions   = "mentions"
+		touches    = "touches"
 	)
 
 	if c.Negate {
 		is = "is not"
 		matches = "does not match"
 		contains = "does not contain"
+		contain = "do not contain"
+		not = "not "
+		has = "does not have"
+		mentions = "does not mention"
+		touches = "does not touch"
 	}
 
-	if c.Type != "" {
+	switch {
+	case len(c.Types) > 0:
+		types := c.Types
+		if c.Type != "" {
+			types = append([]string{c.Type}, types...)
+		}
+		conditions = append(conditions, fmt.Sprintf("type %s any of %s", is, quoteList(types)))
+	case c.Type != "":
 		conditions = append(conditions, fmt.Sprintf("type %s %q", is, c.Type))
 	}
 
@@ -108,11 +968,11 @@ func (c Condition) String() string {
 	}
 
 	if c.ComparePublic {
-		switch c.Public {
+		switch c.Public != c.Negate {
 		case true:
-			conditions = append(conditions, fmt.Sprintf("event %s public", is))
+			conditions = append(conditions, "event is public")
 		case false:
-			conditions = append(conditions, fmt.Sprintf("event %s not public", is))
+			conditions = append(conditions, "event is not public")
 		}
 	}
 
@@ -124,123 +984,1341 @@ func (c Condition) String() string {
 		conditions = append(conditions, fmt.Sprintf("repository ID %s %d", is, c.RepositoryID))
 	}
 
-	return fmt.Sprintf("If %v", strings.Join(conditions, " AND "))
-}
+	if c.PayloadReactionContent != "" {
+		conditions = append(conditions, fmt.Sprintf("payload reaction content %s %q", is, c.PayloadReactionContent))
+	}
 
-// Matches returns false if any test fails. In other words, it returns true if all
-// tests pass or no tests are set.
-// TODO rename to Test?
-func (c *Condition) Matches(event *github.Event) bool {
-	if c.Type != "" && event.GetType() != c.Type {
-		return c.Negate
+	if c.PayloadCommitCommentPathRegexp != "" {
+		conditions = append(conditions, fmt.Sprintf("payload commit comment path %s regexp %q", matches, c.PayloadCommitCommentPathRegexp))
 	}
-	if c.PayloadAction != "" {
-		if event.RawPayload == nil {
-			return false
-		}
-		var payload struct {
-			Action string `json:"action"`
-		}
-		if err := json.Unmarshal(*event.RawPayload, &payload); err != nil {
-			// TODO return, log, ignore? could just be the payload doesn't have an action?
-			return false
-		}
-		if strings.ToLower(payload.Action) != strings.ToLower(c.PayloadAction) {
-			return c.Negate
-		}
+
+	if len(c.PayloadIssueAuthorAssociations) > 0 {
+		conditions = append(conditions, fmt.Sprintf("payload issue author association %s any of %s", is, quoteList(c.PayloadIssueAuthorAssociations)))
 	}
-	if c.PayloadIssueLabel != "" {
-		if event.RawPayload == nil {
-			return false
-		}
-		var payload struct {
-			Issue struct {
-				Labels []string `json:"labels"`
-			} `json:"issue"`
-		}
-		if err := json.Unmarshal(*event.RawPayload, &payload); err != nil {
-			// May not have issue.labels
-			return false
-		}
-		found := false
-		for _, label := range payload.Issue.Labels {
-			if strings.ToLower(label) == strings.ToLower(c.PayloadIssueLabel) {
-				found = true
-			}
-		}
-		if !found {
-			return c.Negate
-		}
+
+	if c.PayloadSizeMin != 0 || c.PayloadSizeMax != 0 {
+		conditions = append(conditions, fmt.Sprintf("payload size %s %s bytes", is, rangeString(c.PayloadSizeMin, c.PayloadSizeMax)))
 	}
-	if c.PayloadIssueMilestoneTitle != "" {
-		if event.RawPayload == nil {
-			return false
-		}
-		var payload struct {
-			Issue struct {
-				Milestone struct {
-					Title string `json:"title"`
-				} `json:"milestone"`
-			} `json:"issue"`
-		}
-		if err := json.Unmarshal(*event.RawPayload, &payload); err != nil {
-			// May not have issue.milestone.title
-			return false
-		}
-		if strings.ToLower(payload.Issue.Milestone.Title) != strings.ToLower(c.PayloadIssueMilestoneTitle) {
-			return c.Negate
-		}
+
+	if c.PayloadMembershipUserLogin != "" {
+		conditions = append(conditions, fmt.Sprintf("payload membership user login %s %q", is, c.PayloadMembershipUserLogin))
 	}
-	if c.PayloadIssueTitleRegexp != "" {
-		if event.RawPayload == nil {
-			return false
-		}
-		var payload struct {
-			Issue struct {
-				Title string `json:"title"`
-			} `json:"issue"`
-		}
-		if err := json.Unmarshal(*event.RawPayload, &payload); err != nil {
-			// May not have issue.title
-			return false
-		}
-		re, err := regexp.Compile(c.PayloadIssueTitleRegexp)
-		if err != nil {
-			return false
-		}
-		if !re.MatchString(payload.Issue.Title) {
-			return c.Negate
+
+	if c.PayloadPullRequestMilestoneTitle != "" {
+		conditions = append(conditions, fmt.Sprintf("payload pull request milestone title %s %q", is, c.PayloadPullRequestMilestoneTitle))
+	}
+
+	if len(c.PayloadActionAny) > 0 {
+		conditions = append(conditions, fmt.Sprintf("payload action %s any of %s", is, quoteList(c.PayloadActionAny)))
+	}
+
+	if len(c.PayloadRepositoryTopicsAny) > 0 {
+		conditions = append(conditions, fmt.Sprintf("payload repository topics %s any of %s", contain, quoteList(c.PayloadRepositoryTopicsAny)))
+	}
+
+	if c.Category != "" {
+		conditions = append(conditions, fmt.Sprintf("event category %s %q", is, c.Category))
+	}
+
+	if !c.PayloadHeadCommitAfter.IsZero() {
+		conditions = append(conditions, fmt.Sprintf("payload head commit %safter %s", not, c.PayloadHeadCommitAfter.Format(time.RFC3339)))
+	}
+
+	if !c.PayloadHeadCommitBefore.IsZero() {
+		conditions = append(conditions, fmt.Sprintf("payload head commit %sbefore %s", not, c.PayloadHeadCommitBefore.Format(time.RFC3339)))
+	}
+
+	if c.ComparePushCreated {
+		switch c.PushCreated != c.Negate {
+		case true:
+			conditions = append(conditions, "payload push created a branch")
+		case false:
+			conditions = append(conditions, "payload push did not create a branch")
 		}
 	}
-	if c.PayloadIssueBodyRegexp != "" {
-		if event.RawPayload == nil {
-			return false
+
+	if c.ComparePushDeleted {
+		switch c.PushDeleted != c.Negate {
+		case true:
+			conditions = append(conditions, "payload push deleted a branch")
+		case false:
+			conditions = append(conditions, "payload push did not delete a branch")
 		}
-		var payload struct {
-			Issue struct {
-				Body string `json:"body"`
-			} `json:"issue"`
+	}
+
+	if c.PayloadPushDistinctAuthorsMin != 0 {
+		conditions = append(conditions, fmt.Sprintf("payload push %s at least %d distinct authors", has, c.PayloadPushDistinctAuthorsMin))
+	}
+
+	if c.PayloadBodyMentions != "" {
+		conditions = append(conditions, fmt.Sprintf("payload body %s %q", mentions, "@"+strings.TrimPrefix(c.PayloadBodyMentions, "@")))
+	}
+
+	if c.PayloadSenderID != 0 {
+		conditions = append(conditions, fmt.Sprintf("payload sender ID %s %d", is, c.PayloadSenderID))
+	}
+
+	if c.PayloadPullRequestIsCrossRepo {
+		conditions = append(conditions, fmt.Sprintf("payload pull request %s cross-repository", is))
+	}
+
+	if c.CompareRepositoryArchived {
+		switch c.RepositoryArchived != c.Negate {
+		case true:
+			conditions = append(conditions, "payload repository is archived")
+		case false:
+			conditions = append(conditions, "payload repository is not archived")
 		}
-		if err := json.Unmarshal(*event.RawPayload, &payload); err != nil {
-			// May not have issue.title
-			return false
+	}
+
+	if c.PayloadProjectNameRegexp != "" {
+		conditions = append(conditions, fmt.Sprintf("payload project name %s regexp %q", matches, c.PayloadProjectNameRegexp))
+	}
+
+	if c.PayloadPushTouchesPath != "" {
+		conditions = append(conditions, fmt.Sprintf("payload push %s path matching %q", touches, c.PayloadPushTouchesPath))
+	}
+
+	if c.PayloadLabelName != "" {
+		conditions = append(conditions, fmt.Sprintf("payload label name %s %q", is, c.PayloadLabelName))
+	}
+
+	if c.MaxAge != 0 {
+		conditions = append(conditions, fmt.Sprintf("event age %s at most %v", is, c.MaxAge))
+	}
+
+	if c.PayloadDeploymentStatusState != "" {
+		conditions = append(conditions, fmt.Sprintf("payload deployment status state %s %q", is, c.PayloadDeploymentStatusState))
+	}
+
+	if c.PayloadCommentCommand != "" {
+		conditions = append(conditions, fmt.Sprintf("payload comment command %s %q", is, c.PayloadCommentCommand))
+	}
+
+	if !c.PayloadIssueMilestoneDueAfter.IsZero() {
+		conditions = append(conditions, fmt.Sprintf("payload issue milestone due %safter %s", not, c.PayloadIssueMilestoneDueAfter.Format(time.RFC3339)))
+	}
+
+	if !c.PayloadIssueMilestoneDueBefore.IsZero() {
+		conditions = append(conditions, fmt.Sprintf("payload issue milestone due %sbefore %s", not, c.PayloadIssueMilestoneDueBefore.Format(time.RFC3339)))
+	}
+
+	if c.ActorAllowed != nil {
+		conditions = append(conditions, fmt.Sprintf("actor %s allowed", is))
+	}
+
+	if c.PayloadPullRequestCommitsMin != 0 || c.PayloadPullRequestCommitsMax != 0 {
+		conditions = append(conditions, fmt.Sprintf("payload pull request commits count %s %s", is, rangeString(c.PayloadPullRequestCommitsMin, c.PayloadPullRequestCommitsMax)))
+	}
+
+	if c.PayloadIssueBodyHasTaskList {
+		conditions = append(conditions, fmt.Sprintf("payload issue body %s a task list", has))
+	}
+
+	if c.PayloadPushFilesChangedMin != 0 || c.PayloadPushFilesChangedMax != 0 {
+		conditions = append(conditions, fmt.Sprintf("payload push files changed %s %s", is, rangeString(c.PayloadPushFilesChangedMin, c.PayloadPushFilesChangedMax)))
+	}
+
+	if c.PayloadRepositoryStarsMin != 0 {
+		conditions = append(conditions, fmt.Sprintf("payload repository stars %s at least %d", is, c.PayloadRepositoryStarsMin))
+	}
+
+	if c.CompareIssueLocked {
+		switch c.IssueLocked != c.Negate {
+		case true:
+			conditions = append(conditions, "payload issue is locked")
+		case false:
+			conditions = append(conditions, "payload issue is not locked")
 		}
-		re, err := regexp.Compile(c.PayloadIssueBodyRegexp)
-		if err != nil {
-			return false
+	}
+
+	if c.PayloadCommentWasEdited {
+		was := "was"
+		if c.Negate {
+			was = "was not"
 		}
-		if !re.MatchString(payload.Issue.Body) {
-			return c.Negate
+		conditions = append(conditions, fmt.Sprintf("payload comment %s edited", was))
+	}
+
+	if c.ActorLogin != "" {
+		conditions = append(conditions, fmt.Sprintf("actor login %s %q", is, c.ActorLogin))
+	}
+
+	if c.ActorID != 0 {
+		conditions = append(conditions, fmt.Sprintf("actor ID %s %d", is, c.ActorID))
+	}
+
+	if c.PayloadPushIsTag {
+		conditions = append(conditions, fmt.Sprintf("payload push %s to a tag", is))
+	}
+
+	if c.BusinessHours != nil {
+		created := "created"
+		if c.Negate {
+			created = "not created"
 		}
+		conditions = append(conditions, fmt.Sprintf("event %s between %v", created, c.BusinessHours))
 	}
-	if c.ComparePublic && event.GetPublic() != c.Public {
-		return c.Negate
+
+	if c.RepositoryName != "" {
+		conditions = append(conditions, fmt.Sprintf("repository name %s %q", is, c.RepositoryName))
 	}
-	if c.OrganizationID != 0 && (event.Org == nil || event.Org.GetID() != c.OrganizationID) {
-		return c.Negate
+
+	if c.PayloadWorkflowJobConclusion != "" {
+		conditions = append(conditions, fmt.Sprintf("payload workflow job conclusion %s %q", is, c.PayloadWorkflowJobConclusion))
 	}
-	if c.RepositoryID != 0 && (event.Repo == nil || event.Repo.GetID() != c.RepositoryID) {
-		return c.Negate
+
+	if c.RepositoryNameGlob != "" {
+		conditions = append(conditions, fmt.Sprintf("repository name %s glob %q", matches, c.RepositoryNameGlob))
+	}
+
+	if len(c.PayloadWorkflowJobLabelsAny) > 0 {
+		conditions = append(conditions, fmt.Sprintf("payload workflow job labels %s any of %s", contain, quoteList(c.PayloadWorkflowJobLabelsAny)))
+	}
+
+	if c.PayloadActionTerminal {
+		conditions = append(conditions, fmt.Sprintf("payload action %s terminal", is))
+	}
+
+	if c.PayloadActionOpening {
+		conditions = append(conditions, fmt.Sprintf("payload action %s opening", is))
+	}
+
+	if c.PayloadCommentLengthMin != 0 || c.PayloadCommentLengthMax != 0 {
+		conditions = append(conditions, fmt.Sprintf("payload comment length %s %s", is, rangeString(c.PayloadCommentLengthMin, c.PayloadCommentLengthMax)))
+	}
+
+	if c.PayloadURLHostRegexp != "" {
+		conditions = append(conditions, fmt.Sprintf("payload URL host %s regexp %q", matches, c.PayloadURLHostRegexp))
+	}
+
+	if c.PayloadPullRequestAction != "" {
+		conditions = append(conditions, fmt.Sprintf("payload pull request action %s %q", is, c.PayloadPullRequestAction))
+	}
+
+	if c.PayloadPullRequestMerged != nil {
+		switch *c.PayloadPullRequestMerged != c.Negate {
+		case true:
+			conditions = append(conditions, "payload pull request is merged")
+		case false:
+			conditions = append(conditions, "payload pull request is not merged")
+		}
+	}
+
+	if c.PayloadPullRequestBaseRef != "" {
+		conditions = append(conditions, fmt.Sprintf("payload pull request base ref %s %q", is, c.PayloadPullRequestBaseRef))
+	}
+
+	if c.PayloadIssueTransferred {
+		was := "was"
+		if c.Negate {
+			was = "was not"
+		}
+		conditions = append(conditions, fmt.Sprintf("payload issue %s transferred", was))
+	}
+
+	if c.PayloadJSONPathBool != nil {
+		conditions = append(conditions, fmt.Sprintf("payload path %q %s %t", c.PayloadJSONPathBool.Path, is, c.PayloadJSONPathBool.Value))
+	}
+
+	if c.PayloadPullRequestHeadRefRegexp != "" {
+		conditions = append(conditions, fmt.Sprintf("payload pull request head ref %s regexp %q", matches, c.PayloadPullRequestHeadRefRegexp))
+	}
+
+	if c.PayloadRef != "" {
+		conditions = append(conditions, fmt.Sprintf("payload ref %s %q", is, c.PayloadRef))
+	}
+
+	if c.PayloadRefRegexp != "" {
+		conditions = append(conditions, fmt.Sprintf("payload ref %s regexp %q", matches, c.PayloadRefRegexp))
+	}
+
+	if c.PayloadCommitsCountMin != 0 || c.PayloadCommitsCountMax != 0 {
+		conditions = append(conditions, fmt.Sprintf("payload commits count %s %s", is, rangeString(c.PayloadCommitsCountMin, c.PayloadCommitsCountMax)))
+	}
+
+	if c.PayloadCommitMessageRegexp != "" {
+		conditions = append(conditions, fmt.Sprintf("payload commit message %s regexp %q", matches, c.PayloadCommitMessageRegexp))
+	}
+
+	if c.PayloadCommentBodyRegexp != "" {
+		conditions = append(conditions, fmt.Sprintf("payload comment body %s regexp %q", matches, c.PayloadCommentBodyRegexp))
+	}
+
+	if c.PayloadSenderIsRepoOwner {
+		conditions = append(conditions, fmt.Sprintf("payload sender %s the repository owner", is))
+	}
+
+	if c.PayloadPackageEcosystem != "" {
+		conditions = append(conditions, fmt.Sprintf("payload package ecosystem %s %q", is, c.PayloadPackageEcosystem))
+	}
+
+	if c.PayloadIssueNumber != 0 {
+		conditions = append(conditions, fmt.Sprintf("payload issue number %s %d", is, c.PayloadIssueNumber))
+	}
+
+	if c.PayloadIssueState != "" {
+		conditions = append(conditions, fmt.Sprintf("payload issue state %s %q", is, c.PayloadIssueState))
+	}
+
+	if c.PayloadSampleRate != 0 {
+		conditions = append(conditions, fmt.Sprintf("event %s in the %.4g%% sample", is, c.PayloadSampleRate*100))
+	}
+
+	if c.PayloadRepositorySizeMin != 0 || c.PayloadRepositorySizeMax != 0 {
+		conditions = append(conditions, fmt.Sprintf("payload repository size %s %s", is, rangeString(c.PayloadRepositorySizeMin, c.PayloadRepositorySizeMax)))
+	}
+
+	if len(c.PayloadIssueLabels) > 0 {
+		conditions = append(conditions, fmt.Sprintf("payload issue labels %s all of %s", contain, quoteList(c.PayloadIssueLabels)))
+	}
+
+	if c.PayloadSecurityAdvisorySeverity != "" {
+		conditions = append(conditions, fmt.Sprintf("payload security advisory severity %s %q", is, c.PayloadSecurityAdvisorySeverity))
+	}
+
+	if c.PayloadHasNoAction {
+		switch c.Negate {
+		case false:
+			conditions = append(conditions, "payload has no action")
+		case true:
+			conditions = append(conditions, "payload has an action")
+		}
+	}
+
+	if !c.CreatedAfter.IsZero() {
+		conditions = append(conditions, fmt.Sprintf("event created %safter %s", not, c.CreatedAfter.Format(time.RFC3339)))
+	}
+
+	if !c.CreatedBefore.IsZero() {
+		conditions = append(conditions, fmt.Sprintf("event created %sbefore %s", not, c.CreatedBefore.Format(time.RFC3339)))
+	}
+
+	if c.PayloadPullRequestRequestedReviewer != "" {
+		conditions = append(conditions, fmt.Sprintf("payload pull request requested reviewer %s %q", is, c.PayloadPullRequestRequestedReviewer))
+	}
+
+	return strings.Join(conditions, " AND ")
+}
+
+// quoteList returns strs formatted as a list of quoted strings, such as
+// ["a", "b"].
+func quoteList(strs []string) string {
+	quoted := make([]string, len(strs))
+	for i, str := range strs {
+		quoted[i] = fmt.Sprintf("%q", str)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// rangeString describes an inclusive range where a zero min or max is
+// unbounded, such as "at least 1 and at most 5".
+func rangeString(min, max int) string {
+	switch {
+	case min != 0 && max != 0:
+		return fmt.Sprintf("at least %d and at most %d", min, max)
+	case max != 0:
+		return fmt.Sprintf("at most %d", max)
+	default:
+		return fmt.Sprintf("at least %d", min)
+	}
+}
+
+// inRange returns true if n is within the inclusive range min and max, a zero
+// min or max is unbounded.
+func inRange(n, min, max int) bool {
+	return (min == 0 || n >= min) && (max == 0 || n <= max)
+}
+
+// inTimeRange returns true if t is after after and before before, a zero after
+// or before is unbounded.
+func inTimeRange(t, after, before time.Time) bool {
+	return (after.IsZero() || t.After(after)) && (before.IsZero() || t.Before(before))
+}
+
+// containsString returns true if strs contains str.
+func containsString(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFold returns true if strs contains str, comparison is case
+// insensitive.
+func containsFold(strs []string, str string) bool {
+	for _, s := range strs {
+		if strings.EqualFold(s, str) {
+			return true
+		}
+	}
+	return false
+}
+
+// handlesType returns false if no event of eventType could match the
+// condition due to its Type or Category.
+func (c Condition) handlesType(eventType string) bool {
+	passes := c.matchesType(eventType) &&
+		(c.Category == "" || containsString(Categories[c.Category], eventType))
+	if !c.Negate {
+		return passes
+	}
+	// A negated condition matches if any test fails, so only excludes an event
+	// type if the type tests are its only tests and they pass.
+	other := c
+	other.Negate, other.Type, other.Types, other.Category = false, "", nil, ""
+	return !passes || !reflect.DeepEqual(other, Condition{})
+}
+
+// matchesType returns true if eventType is Type or any of Types, or if neither
+// is set.
+func (c *Condition) matchesType(eventType string) bool {
+	if c.Type == "" && len(c.Types) == 0 {
+		return true
+	}
+	return (c.Type != "" && c.Type == eventType) || containsString(c.Types, eventType)
+}
+
+// regexps returns the condition's non-empty regular expressions.
+func (c *Condition) regexps() []string {
+	var exprs []string
+	for _, expr := range []string{
+		c.PayloadIssueTitleRegexp,
+		c.PayloadIssueBodyRegexp,
+		c.PayloadCommitCommentPathRegexp,
+		c.PayloadProjectNameRegexp,
+		c.PayloadPushTouchesPath,
+		c.PayloadURLHostRegexp,
+		c.PayloadPullRequestHeadRefRegexp,
+		c.PayloadRefRegexp,
+		c.PayloadCommitMessageRegexp,
+		c.PayloadCommentBodyRegexp,
+	} {
+		if expr != "" {
+			exprs = append(exprs, expr)
+		}
+	}
+	return exprs
+}
+
+// equalString returns true if a and b are equal, ignoring case unless
+// CaseSensitive is set.
+func (c *Condition) equalString(a, b string) bool {
+	if c.CaseSensitive {
+		return a == b
+	}
+	return strings.ToLower(a) == strings.ToLower(b)
+}
+
+// mentionsRegexp returns the regular expression matching a mention of login,
+// with or without a leading @, in a body.
+func mentionsRegexp(login string) string {
+	return `(?i)(?:^|[^\w@-])@` + regexp.QuoteMeta(strings.TrimPrefix(login, "@")) + `(?:[^\w-]|$)`
+}
+
+// Compile compiles the condition's regular expressions, caching them for
+// subsequent calls to Matches, instead of compiling them for each event.
+// Returns an error if any regular expression is invalid. Compile must not be
+// called concurrently with Matches. Regular expression fields modified after
+// Compile are compiled for each event until Compile is called again.
+func (c *Condition) Compile() error {
+	if err := c.checkRegexps(); err != nil {
+		return err
+	}
+	exprs := c.regexps()
+	if c.PayloadBodyMentions != "" {
+		exprs = append(exprs, mentionsRegexp(c.PayloadBodyMentions))
+	}
+	if len(exprs) == 0 {
+		c.compiled = nil
+		return nil
+	}
+	compiled := make(map[string]*regexp.Regexp, len(exprs))
+	for _, expr := range exprs {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return err
+		}
+		compiled[expr] = re
+	}
+	c.compiled = compiled
+	return nil
+}
+
+// regexp returns the compiled expr, from the cache populated by Compile if
+// present, otherwise expr is compiled.
+func (c *Condition) regexp(expr string) (*regexp.Regexp, error) {
+	if re, ok := c.compiled[expr]; ok {
+		return re, nil
+	}
+	return regexp.Compile(expr)
+}
+
+// checkRegexps returns an error if any of the condition's regular expressions,
+// or its glob pattern, are invalid.
+func (c *Condition) checkRegexps() error {
+	for _, expr := range c.regexps() {
+		if _, err := regexp.Compile(expr); err != nil {
+			return err
+		}
+	}
+	if c.RepositoryNameGlob != "" {
+		if _, err := path.Match(c.RepositoryNameGlob, ""); err != nil {
+			return fmt.Errorf("invalid glob %q: %v", c.RepositoryNameGlob, err)
+		}
+	}
+	return nil
+}
+
+// Matches returns false if any test fails. In other words, it returns true if all
+// tests pass or no tests are set. A test which cannot be evaluated, see Test,
+// fails.
+func (c *Condition) Matches(event *github.Event) bool {
+	return c.matches(event, time.Now)
+}
+
+// MatchesIgnoringType is like Matches but skips the Type and Types tests, such as
+// to reuse a condition's payload tests for several event types while checking
+// the type separately. Category is still tested.
+func (c *Condition) MatchesIgnoringType(event *github.Event) bool {
+	other := *c
+	other.Type, other.Types = "", nil
+	return other.matches(event, time.Now)
+}
+
+// Test is like Matches but returns an error if a test could not be evaluated,
+// such as when the event's payload is not valid JSON or a regular expression
+// is invalid. The result is false when an error is returned.
+func (c *Condition) Test(event *github.Event) (bool, error) {
+	return c.matchesPayload(event, time.Now, &lazyPayload{event: event})
+}
+
+// MatchesWithClock is like Matches but uses now as the current time for time
+// based tests, such as MaxAge.
+func (c *Condition) MatchesWithClock(event *github.Event, now time.Time) bool {
+	return c.matches(event, func() time.Time { return now })
+}
+
+// MatchesWithPayload is like Matches but reads payload fields from payload,
+// instead of decoding the event's RawPayload, for callers which have already
+// unmarshalled the payload, such as into a *github.IssuesEvent.
+//
+// Payload must be a struct, or pointer to struct, whose fields are named by
+// their json tags as in GitHub's payload, such as `json:"action"` and
+// `json:"issue"`, or a map[string]interface{} as decoded by encoding/json.
+// Nested structs, pointers, slices and maps are followed, and fields which
+// are absent or nil are treated as empty. A nil payload does not match any
+// payload test, except PayloadHasNoAction, as for an event without a
+// RawPayload.
+func (c *Condition) MatchesWithPayload(event *github.Event, payload interface{}) bool {
+	p := lazyPayload{event: event, decoded: true, payload: new(decodedPayload), source: payload, hasSource: true}
+	if payload == nil {
+		p.decodeErr = errNoPayload
+	} else {
+		p.invalid, p.decodeErr = fillPayloadFields(p.payload, reflect.ValueOf(payload))
+	}
+	matched, _ := c.matchesPayload(event, time.Now, &p)
+	return matched
+}
+
+// matches implements Matches and MatchesWithClock, now is only called if a
+// time based test is set.
+func (c *Condition) matches(event *github.Event, now func() time.Time) bool {
+	matched, _ := c.matchesPayload(event, now, &lazyPayload{event: event})
+	return matched
+}
+
+// matchesPayload implements matches and Test using p for payload tests.
+func (c *Condition) matchesPayload(event *github.Event, now func() time.Time, p *lazyPayload) (bool, error) {
+	if !c.matchesType(event.GetType()) {
+		return c.Negate, nil
+	}
+	if c.PayloadAction != "" {
+		payload, ok := p.get("action")
+		if !ok {
+			return false, p.err
+		}
+		if !c.equalString(payload.Action, c.PayloadAction) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadIssueLabel != "" {
+		payload, ok := p.get("issue")
+		if !ok {
+			return false, p.err
+		}
+		labels, ok := payload.issueLabels()
+		if !ok {
+			// May not have issue.labels
+			return false, nil
+		}
+		found := false
+		for _, label := range labels {
+			if c.equalString(label, c.PayloadIssueLabel) {
+				found = true
+			}
+		}
+		if !found {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadIssueMilestoneTitle != "" {
+		payload, ok := p.get("issue")
+		if !ok {
+			return false, p.err
+		}
+		if !c.equalString(payload.Issue.Milestone.Title, c.PayloadIssueMilestoneTitle) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadIssueTitleRegexp != "" {
+		payload, ok := p.get("issue")
+		if !ok {
+			return false, p.err
+		}
+		re, err := c.regexp(c.PayloadIssueTitleRegexp)
+		if err != nil {
+			return false, err
+		}
+		if !re.MatchString(payload.Issue.Title) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadIssueBodyRegexp != "" {
+		payload, ok := p.get("issue")
+		if !ok {
+			return false, p.err
+		}
+		re, err := c.regexp(c.PayloadIssueBodyRegexp)
+		if err != nil {
+			return false, err
+		}
+		if !re.MatchString(payload.Issue.Body) {
+			return c.Negate, nil
+		}
+	}
+	if c.ComparePublic && event.GetPublic() != c.Public {
+		return c.Negate, nil
+	}
+	if c.OrganizationID != 0 && (event.Org == nil || event.Org.GetID() != c.OrganizationID) {
+		return c.Negate, nil
+	}
+	if c.RepositoryID != 0 && (event.Repo == nil || event.Repo.GetID() != c.RepositoryID) {
+		return c.Negate, nil
+	}
+	if c.PayloadReactionContent != "" {
+		payload, ok := p.get("reaction")
+		if !ok {
+			return false, p.err
+		}
+		if payload.Reaction.Content != c.PayloadReactionContent {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadCommitCommentPathRegexp != "" {
+		payload, ok := p.get("comment")
+		if !ok {
+			return false, p.err
+		}
+		re, err := c.regexp(c.PayloadCommitCommentPathRegexp)
+		if err != nil {
+			return false, err
+		}
+		if path, _ := payload.comment(); !re.MatchString(path) {
+			return c.Negate, nil
+		}
+	}
+	if len(c.PayloadIssueAuthorAssociations) > 0 {
+		payload, ok := p.get("issue")
+		if !ok {
+			return false, p.err
+		}
+		if !containsFold(c.PayloadIssueAuthorAssociations, payload.Issue.AuthorAssociation) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadSizeMin != 0 || c.PayloadSizeMax != 0 {
+		if event.RawPayload == nil {
+			return false, nil
+		}
+		if !inRange(len(*event.RawPayload), c.PayloadSizeMin, c.PayloadSizeMax) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadMembershipUserLogin != "" {
+		payload, ok := p.get("membership")
+		if !ok {
+			return false, p.err
+		}
+		if !strings.EqualFold(payload.Membership.User.Login, c.PayloadMembershipUserLogin) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadPullRequestMilestoneTitle != "" {
+		payload, ok := p.get("pull_request")
+		if !ok || payload.PullRequest == nil || payload.PullRequest.Milestone == nil {
+			return false, p.err
+		}
+		if !strings.EqualFold(payload.PullRequest.Milestone.Title, c.PayloadPullRequestMilestoneTitle) {
+			return c.Negate, nil
+		}
+	}
+	if len(c.PayloadActionAny) > 0 {
+		payload, ok := p.get("action")
+		if !ok {
+			return false, p.err
+		}
+		if !containsFold(c.PayloadActionAny, payload.Action) {
+			return c.Negate, nil
+		}
+	}
+	if len(c.PayloadRepositoryTopicsAny) > 0 {
+		payload, ok := p.get("repository")
+		if !ok || payload.Repository.Topics == nil {
+			return false, p.err
+		}
+		found := false
+		for _, topic := range payload.Repository.Topics {
+			if containsFold(c.PayloadRepositoryTopicsAny, topic) {
+				found = true
+			}
+		}
+		if !found {
+			return c.Negate, nil
+		}
+	}
+	if c.Category != "" && !containsString(Categories[c.Category], event.GetType()) {
+		return c.Negate, nil
+	}
+	if !c.PayloadHeadCommitAfter.IsZero() || !c.PayloadHeadCommitBefore.IsZero() {
+		payload, ok := p.get("head_commit")
+		if !ok {
+			return false, p.err
+		}
+		timestamp, err := time.Parse(time.RFC3339, payload.HeadCommit.Timestamp)
+		if err != nil {
+			// May not have head_commit.timestamp
+			return false, nil
+		}
+		if !inTimeRange(timestamp, c.PayloadHeadCommitAfter, c.PayloadHeadCommitBefore) {
+			return c.Negate, nil
+		}
+	}
+	if c.ComparePushCreated || c.ComparePushDeleted {
+		payload, ok := p.get("created", "deleted")
+		if !ok {
+			return false, p.err
+		}
+		if c.ComparePushCreated && payload.Created != c.PushCreated {
+			return c.Negate, nil
+		}
+		if c.ComparePushDeleted && payload.Deleted != c.PushDeleted {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadPushDistinctAuthorsMin != 0 {
+		payload, ok := p.get("commits")
+		if !ok {
+			return false, p.err
+		}
+		authors := make(map[string]struct{})
+		for _, commit := range payload.Commits {
+			authors[commit.Author.Email] = struct{}{}
+		}
+		if len(authors) < c.PayloadPushDistinctAuthorsMin {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadBodyMentions != "" {
+		payload, ok := p.get("issue", "comment")
+		if !ok {
+			return false, p.err
+		}
+		body := payload.Issue.Body
+		if containsString(commentEventTypes, event.GetType()) {
+			_, body = payload.comment()
+		}
+		re, err := c.regexp(mentionsRegexp(c.PayloadBodyMentions))
+		if err != nil {
+			return false, err
+		}
+		if !re.MatchString(body) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadSenderID != 0 {
+		payload, ok := p.get("sender")
+		if !ok {
+			return false, p.err
+		}
+		if payload.Sender.ID != c.PayloadSenderID {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadPullRequestIsCrossRepo {
+		payload, ok := p.get("pull_request")
+		if !ok || payload.PullRequest == nil || payload.PullRequest.Base.Repo.FullName == "" {
+			return false, p.err
+		}
+		if payload.PullRequest.Head.Repo.FullName == payload.PullRequest.Base.Repo.FullName {
+			return c.Negate, nil
+		}
+	}
+	if c.CompareRepositoryArchived {
+		payload, ok := p.get("repository")
+		if !ok {
+			return false, p.err
+		}
+		if payload.Repository.Archived != c.RepositoryArchived {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadProjectNameRegexp != "" {
+		payload, ok := p.get("project")
+		if !ok {
+			return false, p.err
+		}
+		re, err := c.regexp(c.PayloadProjectNameRegexp)
+		if err != nil {
+			return false, err
+		}
+		if !re.MatchString(payload.Project.Name) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadPushTouchesPath != "" {
+		payload, ok := p.get("commits")
+		if !ok {
+			return false, p.err
+		}
+		re, err := c.regexp(c.PayloadPushTouchesPath)
+		if err != nil {
+			return false, err
+		}
+		found := false
+		for _, commit := range payload.Commits {
+			for _, files := range [][]string{commit.Added, commit.Modified, commit.Removed} {
+				for _, file := range files {
+					if re.MatchString(file) {
+						found = true
+					}
+				}
+			}
+		}
+		if !found {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadLabelName != "" {
+		payload, ok := p.get("label")
+		if !ok {
+			return false, p.err
+		}
+		if !strings.EqualFold(payload.Label.Name, c.PayloadLabelName) {
+			return c.Negate, nil
+		}
+	}
+	if c.MaxAge != 0 {
+		if event.GetCreatedAt().IsZero() {
+			return false, nil
+		}
+		if now().Sub(event.GetCreatedAt()) > c.MaxAge {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadDeploymentStatusState != "" {
+		payload, ok := p.get("deployment_status")
+		if !ok {
+			return false, p.err
+		}
+		if !strings.EqualFold(payload.DeploymentStatus.State, c.PayloadDeploymentStatusState) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadCommentCommand != "" {
+		payload, ok := p.get("comment")
+		if !ok {
+			return false, p.err
+		}
+		_, body := payload.comment()
+		fields := strings.Fields(body)
+		if len(fields) == 0 || !strings.EqualFold(fields[0], c.PayloadCommentCommand) {
+			return c.Negate, nil
+		}
+	}
+	if !c.PayloadIssueMilestoneDueAfter.IsZero() || !c.PayloadIssueMilestoneDueBefore.IsZero() {
+		payload, ok := p.get("issue")
+		if !ok {
+			return false, p.err
+		}
+		dueOn, err := time.Parse(time.RFC3339, payload.Issue.Milestone.DueOn)
+		if err != nil {
+			// May not have issue.milestone.due_on
+			return false, nil
+		}
+		if !inTimeRange(dueOn, c.PayloadIssueMilestoneDueAfter, c.PayloadIssueMilestoneDueBefore) {
+			return c.Negate, nil
+		}
+	}
+	if c.ActorAllowed != nil {
+		if event.Actor == nil {
+			return false, nil
+		}
+		if !c.ActorAllowed(event.Actor.GetLogin()) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadPullRequestCommitsMin != 0 || c.PayloadPullRequestCommitsMax != 0 {
+		payload, ok := p.get("pull_request")
+		if !ok {
+			return false, p.err
+		}
+		var commits int
+		if payload.PullRequest != nil {
+			commits = payload.PullRequest.Commits
+		}
+		if !inRange(commits, c.PayloadPullRequestCommitsMin, c.PayloadPullRequestCommitsMax) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadIssueBodyHasTaskList {
+		payload, ok := p.get("issue")
+		if !ok {
+			return false, p.err
+		}
+		if !taskListRegexp.MatchString(payload.Issue.Body) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadPushFilesChangedMin != 0 || c.PayloadPushFilesChangedMax != 0 {
+		payload, ok := p.get("commits")
+		if !ok {
+			return false, p.err
+		}
+		files := make(map[string]struct{})
+		for _, commit := range payload.Commits {
+			for _, changed := range [][]string{commit.Added, commit.Modified, commit.Removed} {
+				for _, file := range changed {
+					files[file] = struct{}{}
+				}
+			}
+		}
+		if !inRange(len(files), c.PayloadPushFilesChangedMin, c.PayloadPushFilesChangedMax) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadRepositoryStarsMin != 0 {
+		payload, ok := p.get("repository")
+		if !ok || payload.Repository.StargazersCount == nil {
+			return false, p.err
+		}
+		if *payload.Repository.StargazersCount < c.PayloadRepositoryStarsMin {
+			return c.Negate, nil
+		}
+	}
+	if c.CompareIssueLocked {
+		payload, ok := p.get("issue")
+		if !ok {
+			return false, p.err
+		}
+		if payload.Issue.Locked != c.IssueLocked {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadCommentWasEdited {
+		payload, ok := p.get("action", "changes")
+		if !ok {
+			return false, p.err
+		}
+		if payload.Action != "edited" || payload.Changes.Body == nil {
+			return c.Negate, nil
+		}
+	}
+	if c.ActorLogin != "" && (event.Actor == nil || !strings.EqualFold(event.Actor.GetLogin(), c.ActorLogin)) {
+		return c.Negate, nil
+	}
+	if c.ActorID != 0 && (event.Actor == nil || event.Actor.GetID() != c.ActorID) {
+		return c.Negate, nil
+	}
+	if c.PayloadPushIsTag {
+		payload, ok := p.get("ref")
+		if !ok {
+			return false, p.err
+		}
+		if !strings.HasPrefix(payload.Ref, "refs/tags/") {
+			return c.Negate, nil
+		}
+	}
+	if c.BusinessHours != nil {
+		if event.GetCreatedAt().IsZero() {
+			return false, nil
+		}
+		if !c.BusinessHours.contains(event.GetCreatedAt()) {
+			return c.Negate, nil
+		}
+	}
+	if c.RepositoryName != "" {
+		if event.Repo == nil {
+			return false, nil
+		}
+		if !strings.EqualFold(event.Repo.GetName(), c.RepositoryName) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadWorkflowJobConclusion != "" {
+		payload, ok := p.get("workflow_job")
+		if !ok {
+			return false, p.err
+		}
+		if !strings.EqualFold(payload.WorkflowJob.Conclusion, c.PayloadWorkflowJobConclusion) {
+			return c.Negate, nil
+		}
+	}
+	if c.RepositoryNameGlob != "" {
+		pattern := strings.ToLower(c.RepositoryNameGlob)
+		if _, err := path.Match(pattern, ""); err != nil {
+			return false, err
+		}
+		if event.Repo == nil {
+			return false, nil
+		}
+		matched, err := path.Match(pattern, strings.ToLower(event.Repo.GetName()))
+		if err != nil {
+			return false, err
+		}
+		if !matched {
+			return c.Negate, nil
+		}
+	}
+	if len(c.PayloadWorkflowJobLabelsAny) > 0 {
+		payload, ok := p.get("workflow_job")
+		if !ok || payload.WorkflowJob.Labels == nil {
+			return false, p.err
+		}
+		found := false
+		for _, label := range payload.WorkflowJob.Labels {
+			if containsFold(c.PayloadWorkflowJobLabelsAny, label) {
+				found = true
+			}
+		}
+		if !found {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadActionTerminal {
+		payload, ok := p.get("action")
+		if !ok {
+			return false, p.err
+		}
+		if !containsFold(TerminalActions, payload.Action) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadActionOpening {
+		payload, ok := p.get("action")
+		if !ok {
+			return false, p.err
+		}
+		if !containsFold(OpeningActions, payload.Action) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadCommentLengthMin != 0 || c.PayloadCommentLengthMax != 0 {
+		payload, ok := p.get("comment")
+		if !ok {
+			return false, p.err
+		}
+		if _, body := payload.comment(); !inRange(utf8.RuneCountInString(body), c.PayloadCommentLengthMin, c.PayloadCommentLengthMax) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadURLHostRegexp != "" {
+		payload, ok := p.get("repository")
+		if !ok {
+			return false, p.err
+		}
+		re, err := c.regexp(c.PayloadURLHostRegexp)
+		if err != nil {
+			return false, err
+		}
+		u, err := url.Parse(payload.Repository.HTMLURL)
+		if err != nil || u.Host == "" {
+			// May not have repository.html_url
+			return false, nil
+		}
+		if !re.MatchString(u.Hostname()) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadPullRequestAction != "" {
+		payload, ok := p.get("pull_request", "action")
+		if !ok {
+			return false, p.err
+		}
+		if payload.PullRequest == nil {
+			return false, nil
+		}
+		if !strings.EqualFold(payload.Action, c.PayloadPullRequestAction) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadPullRequestMerged != nil {
+		payload, ok := p.get("pull_request")
+		if !ok {
+			return false, p.err
+		}
+		if payload.PullRequest == nil || payload.PullRequest.Merged == nil {
+			return false, nil
+		}
+		if *payload.PullRequest.Merged != *c.PayloadPullRequestMerged {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadPullRequestBaseRef != "" {
+		payload, ok := p.get("pull_request")
+		if !ok {
+			return false, p.err
+		}
+		if payload.PullRequest == nil || payload.PullRequest.Base.Ref == "" {
+			return false, nil
+		}
+		if !c.equalString(payload.PullRequest.Base.Ref, c.PayloadPullRequestBaseRef) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadIssueTransferred {
+		payload, ok := p.get("action", "changes")
+		if !ok {
+			return false, p.err
+		}
+		if payload.Action != "transferred" || (payload.Changes.NewIssue == nil && payload.Changes.NewRepository == nil) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadJSONPathBool != nil {
+		tree, ok := p.getTree()
+		if !ok {
+			return false, p.treeErr
+		}
+		if !c.PayloadJSONPathBool.matches(tree) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadPullRequestHeadRefRegexp != "" {
+		payload, ok := p.get("pull_request")
+		if !ok {
+			return false, p.err
+		}
+		re, err := c.regexp(c.PayloadPullRequestHeadRefRegexp)
+		if err != nil {
+			return false, err
+		}
+		if payload.PullRequest == nil || payload.PullRequest.Head.Ref == "" {
+			return false, nil
+		}
+		if !re.MatchString(payload.PullRequest.Head.Ref) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadRef != "" {
+		payload, ok := p.get("ref")
+		if !ok || payload.Ref == "" {
+			return false, p.err
+		}
+		if payload.Ref != c.PayloadRef {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadRefRegexp != "" {
+		payload, ok := p.get("ref")
+		if !ok {
+			return false, p.err
+		}
+		re, err := c.regexp(c.PayloadRefRegexp)
+		if err != nil {
+			return false, err
+		}
+		if payload.Ref == "" {
+			return false, nil
+		}
+		if !re.MatchString(payload.Ref) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadCommitsCountMin != 0 || c.PayloadCommitsCountMax != 0 {
+		payload, ok := p.get("commits")
+		if !ok || payload.Commits == nil {
+			return false, p.err
+		}
+		if !inRange(len(payload.Commits), c.PayloadCommitsCountMin, c.PayloadCommitsCountMax) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadCommitMessageRegexp != "" {
+		payload, ok := p.get("commits")
+		if !ok {
+			return false, p.err
+		}
+		re, err := c.regexp(c.PayloadCommitMessageRegexp)
+		if err != nil {
+			return false, err
+		}
+		if payload.Commits == nil {
+			return false, nil
+		}
+		var found bool
+		for _, commit := range payload.Commits {
+			if re.MatchString(commit.Message) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadCommentBodyRegexp != "" {
+		payload, ok := p.get("comment")
+		if !ok {
+			return false, p.err
+		}
+		re, err := c.regexp(c.PayloadCommentBodyRegexp)
+		if err != nil {
+			return false, err
+		}
+		if payload.Comment == nil {
+			return false, nil
+		}
+		if !re.MatchString(payload.Comment.Body) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadSenderIsRepoOwner {
+		payload, ok := p.get("sender", "repository")
+		if !ok || payload.Sender.Login == "" || payload.Repository.Owner.Login == "" {
+			return false, p.err
+		}
+		if !strings.EqualFold(payload.Sender.Login, payload.Repository.Owner.Login) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadPackageEcosystem != "" {
+		payload, ok := p.get("package")
+		if !ok {
+			return false, p.err
+		}
+		ecosystem := payload.Package.PackageType
+		if ecosystem == "" {
+			ecosystem = payload.Package.Ecosystem
+		}
+		if ecosystem == "" {
+			return false, nil
+		}
+		if !strings.EqualFold(ecosystem, c.PayloadPackageEcosystem) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadIssueNumber != 0 {
+		payload, ok := p.get("issue", "pull_request")
+		if !ok {
+			return false, p.err
+		}
+		number := payload.Issue.Number
+		if number == 0 && payload.PullRequest != nil {
+			number = payload.PullRequest.Number
+		}
+		if number == 0 {
+			return false, nil
+		}
+		if number != c.PayloadIssueNumber {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadIssueState != "" {
+		payload, ok := p.get("issue")
+		if !ok || payload.Issue.State == "" {
+			return false, p.err
+		}
+		if !strings.EqualFold(payload.Issue.State, c.PayloadIssueState) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadSampleRate != 0 {
+		if event.ID == nil && c.PayloadSampleRate < 1 {
+			return false, nil
+		}
+		if !inSample(event.GetID(), c.PayloadSampleRate) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadRepositorySizeMin != 0 || c.PayloadRepositorySizeMax != 0 {
+		payload, ok := p.get("repository")
+		if !ok || payload.Repository.Size == nil {
+			return false, p.err
+		}
+		if !inRange(*payload.Repository.Size, c.PayloadRepositorySizeMin, c.PayloadRepositorySizeMax) {
+			return c.Negate, nil
+		}
+	}
+	if len(c.PayloadIssueLabels) > 0 {
+		payload, ok := p.get("issue")
+		if !ok {
+			return false, p.err
+		}
+		labels, ok := payload.issueLabels()
+		if !ok {
+			// May not have issue.labels
+			return false, nil
+		}
+		for _, want := range c.PayloadIssueLabels {
+			found := false
+			for _, label := range labels {
+				if c.equalString(label, want) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return c.Negate, nil
+			}
+		}
+	}
+	if c.PayloadSecurityAdvisorySeverity != "" {
+		payload, ok := p.get("security_advisory")
+		if !ok || payload.SecurityAdvisory.Severity == "" {
+			return false, p.err
+		}
+		if !strings.EqualFold(payload.SecurityAdvisory.Severity, c.PayloadSecurityAdvisorySeverity) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadHasNoAction {
+		payload, ok := p.get("action")
+		if !ok && p.err != nil && p.err != errNoPayload {
+			return false, p.err
+		}
+		if ok && payload.Action != "" {
+			return c.Negate, nil
+		}
+	}
+	if !c.CreatedAfter.IsZero() || !c.CreatedBefore.IsZero() {
+		if event.GetCreatedAt().IsZero() {
+			return false, nil
+		}
+		if !inTimeRange(event.GetCreatedAt(), c.CreatedAfter, c.CreatedBefore) {
+			return c.Negate, nil
+		}
+	}
+	if c.PayloadPullRequestRequestedReviewer != "" {
+		payload, ok := p.get("requested_reviewer")
+		if !ok || payload.RequestedReviewer.Login == "" {
+			return false, p.err
+		}
+		if !strings.EqualFold(payload.RequestedReviewer.Login, c.PayloadPullRequestRequestedReviewer) {
+			return c.Negate, nil
+		}
 	}
-	return !c.Negate
+	return !c.Negate, nil
 }
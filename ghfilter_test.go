@@ -3,7 +3,9 @@ package ghfilter
 import (
 	"encoding/json"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-github/github"
 )
@@ -51,6 +53,309 @@ func TestFilter_matches(t *testing.T) {
 	}
 }
 
+func TestFilter_funcs(t *testing.T) {
+	filter := Filter{
+		Conditions: []Condition{
+			{Type: "IssuesEvent"},
+		},
+		Funcs: []func(*github.Event) bool{
+			func(event *github.Event) bool {
+				return event.GetActor().GetLogin() == "octocat"
+			},
+		},
+	}
+
+	tests := []struct {
+		event *github.Event
+		want  bool
+	}{
+		{
+			event: &github.Event{
+				Type:  github.String("IssuesEvent"),
+				Actor: &github.User{Login: github.String("octocat")},
+			},
+			want: true,
+		},
+		{
+			event: &github.Event{
+				Type:  github.String("IssuesEvent"),
+				Actor: &github.User{Login: github.String("other")}, // we want octocat
+			},
+			want: false,
+		},
+		{
+			event: &github.Event{
+				Type:  github.String("PushEvent"), // We want IssuesEvent
+				Actor: &github.User{Login: github.String("octocat")},
+			},
+			want: false,
+		},
+	}
+
+	for _, test := range tests {
+		have := filter.Matches(test.event)
+		if have != test.want {
+			t.Errorf("have: %v, want %v", have, test.want)
+		}
+	}
+
+	if _, err := json.Marshal(filter); err != nil {
+		t.Errorf("unexpected error marshalling filter with funcs: %v", err)
+	}
+}
+
+func TestFilter_matchesErr(t *testing.T) {
+	payload := json.RawMessage(`{"issue":{"title":"title"}}`)
+	event := &github.Event{RawPayload: &payload}
+
+	filter := Filter{
+		Conditions: []Condition{
+			{PayloadIssueTitleRegexp: "("},
+		},
+	}
+
+	matched, err := filter.MatchesErr(event)
+	if matched || err != nil {
+		t.Errorf("non-strict have: %v, %v want: false, nil", matched, err)
+	}
+
+	filter.StrictRegexp = true
+	matched, err = filter.MatchesErr(event)
+	if matched || err == nil {
+		t.Errorf("strict have: %v, %v want: false, non-nil error", matched, err)
+	}
+}
+
+func TestFilter_reasonsAll(t *testing.T) {
+	filter := Filter{
+		Conditions: []Condition{
+			{Type: "IssuesEvent"},
+			{PayloadAction: "opened"},
+			{ComparePublic: true, Public: true},
+			{},
+			{Type: "PushEvent", RepositoryName: "owner/repo"},
+		},
+	}
+
+	payload := json.RawMessage(`{"action":"closed"}`)
+	event := &github.Event{
+		Type:       github.String("IssuesEvent"),
+		Public:     github.Bool(true),
+		RawPayload: &payload,
+	}
+
+	want := []string{
+		`PASS: type is "IssuesEvent"`,
+		`FAIL: payload action is "opened" (got payload action "closed")`,
+		`PASS: event is public`,
+		`PASS: (no tests)`,
+		`FAIL: type is "PushEvent" AND repository name is "owner/repo" (got type "IssuesEvent")`,
+	}
+
+	if have := filter.ReasonsAll(event); !reflect.DeepEqual(have, want) {
+		t.Errorf("reasons do not match\nhave: %q\nwant: %q", have, want)
+	}
+}
+
+func TestFilter_explain(t *testing.T) {
+	filter := Filter{
+		Conditions: []Condition{
+			{Type: "IssuesEvent"},
+			{PayloadAction: "opened"},
+			{ComparePublic: true, Public: true},
+			{RepositoryID: 1, Negate: true},
+		},
+		Logic: Or,
+	}
+
+	payload := json.RawMessage(`{"action":"closed"}`)
+	event := &github.Event{
+		Type:       github.String("IssuesEvent"),
+		Public:     github.Bool(true),
+		Repo:       &github.Repository{ID: github.Int(1)},
+		RawPayload: &payload,
+	}
+
+	want := []ConditionResult{
+		{Index: 0, Passed: true, Description: `If type is "IssuesEvent"`},
+		{Index: 1, Passed: false, Description: `If payload action is "opened"`},
+		{Index: 2, Passed: true, Description: `If event is public`},
+		{Index: 3, Passed: false, Description: `If repository ID is not 1`},
+	}
+
+	have := filter.Explain(event)
+	if len(have) != len(filter.Conditions) {
+		t.Fatalf("have %d results, want one per condition: %d", len(have), len(filter.Conditions))
+	}
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("results do not match\nhave: %+v\nwant: %+v", have, want)
+	}
+
+	if have := (&Filter{}).Explain(event); len(have) != 0 {
+		t.Errorf("have %d results for filter without conditions, want 0", len(have))
+	}
+}
+
+func TestFilter_handlesType(t *testing.T) {
+	tests := []struct {
+		filter    Filter
+		eventType string
+		want      bool
+	}{
+		{Filter{}, "PushEvent", true},
+		{Filter{Conditions: []Condition{{PayloadAction: "opened"}}}, "PushEvent", true},
+		{Filter{Conditions: []Condition{{Type: "IssuesEvent"}}}, "IssuesEvent", true},
+		{Filter{Conditions: []Condition{{Type: "IssuesEvent"}}}, "PushEvent", false},
+		{Filter{Conditions: []Condition{{Type: "IssuesEvent"}, {PayloadAction: "opened"}}}, "PushEvent", false},
+		{Filter{Conditions: []Condition{{Category: "comment"}}}, "IssueCommentEvent", true},
+		{Filter{Conditions: []Condition{{Category: "comment"}}}, "IssuesEvent", false},
+		{Filter{Conditions: []Condition{{Type: "PushEvent", Negate: true}}}, "PushEvent", false},
+		{Filter{Conditions: []Condition{{Type: "PushEvent", Negate: true}}}, "IssuesEvent", true},
+		{Filter{Conditions: []Condition{{Type: "PushEvent", PayloadAction: "opened", Negate: true}}}, "PushEvent", true},
+		{Filter{Conditions: []Condition{{Types: []string{"IssuesEvent", "PullRequestEvent"}}}}, "PullRequestEvent", true},
+		{Filter{Conditions: []Condition{{Types: []string{"IssuesEvent", "PullRequestEvent"}}}}, "PushEvent", false},
+		{Filter{Conditions: []Condition{{Type: "PushEvent", Types: []string{"IssuesEvent"}}}}, "PushEvent", true},
+		{Filter{Conditions: []Condition{{Types: []string{"PushEvent"}, Negate: true}}}, "PushEvent", false},
+	}
+
+	for _, test := range tests {
+		if have := test.filter.HandlesType(test.eventType); have != test.want {
+			t.Errorf("filter %+v HandlesType(%q) have: %v, want: %v", test.filter.Conditions, test.eventType, have, test.want)
+		}
+	}
+}
+
+func TestFilter_rejectionHistogram(t *testing.T) {
+	filter := Filter{
+		Conditions: []Condition{
+			{ComparePublic: true, Public: true},
+			{Type: "IssuesEvent"},
+			{RepositoryID: 1},
+		},
+	}
+
+	events := []*github.Event{
+		{Public: github.Bool(false), Type: github.String("IssuesEvent")},                                             // 0
+		{Public: github.Bool(false), Type: github.String("PushEvent")},                                               // 0
+		{Public: github.Bool(true), Type: github.String("PushEvent")},                                                // 1
+		{Public: github.Bool(true), Type: github.String("IssuesEvent"), Repo: &github.Repository{ID: github.Int(2)}}, // 2
+		{Public: github.Bool(true), Type: github.String("IssuesEvent"), Repo: &github.Repository{ID: github.Int(1)}}, // match
+	}
+
+	want := map[int]int{0: 2, 1: 1, 2: 1}
+	if have := filter.RejectionHistogram(events); !reflect.DeepEqual(have, want) {
+		t.Errorf("histogram does not match\nhave: %v\nwant: %v", have, want)
+	}
+}
+
+func TestFilter_excludeTypes(t *testing.T) {
+	filter := Filter{
+		Conditions: []Condition{
+			{ComparePublic: true, Public: true},
+		},
+		ExcludeTypes: []string{"WatchEvent", "ForkEvent"},
+	}
+
+	tests := []struct {
+		event *github.Event
+		want  bool
+	}{
+		{
+			event: &github.Event{Type: github.String("IssuesEvent"), Public: github.Bool(true)},
+			want:  true,
+		},
+		{
+			// Excluded even though all conditions match
+			event: &github.Event{Type: github.String("WatchEvent"), Public: github.Bool(true)},
+			want:  false,
+		},
+		{
+			event: &github.Event{Type: github.String("ForkEvent"), Public: github.Bool(true)},
+			want:  false,
+		},
+	}
+
+	for _, test := range tests {
+		have := filter.Matches(test.event)
+		if have != test.want {
+			t.Errorf("have: %v, want %v", have, test.want)
+		}
+	}
+
+	if filter.HandlesType("WatchEvent") {
+		t.Errorf("expected excluded type to not be handled")
+	}
+}
+
+func TestFilter_logic(t *testing.T) {
+	issues := &github.Event{Type: github.String("IssuesEvent"), Public: github.Bool(true)}
+	watch := &github.Event{Type: github.String("WatchEvent"), Public: github.Bool(false)}
+	fork := &github.Event{Type: github.String("ForkEvent"), Public: github.Bool(true)}
+
+	conditions := []Condition{
+		{Type: "IssuesEvent"},
+		{ComparePublic: true, Public: true},
+	}
+
+	tests := []struct {
+		filter Filter
+		event  *github.Event
+		want   bool
+	}{
+		{filter: Filter{Logic: And}, event: issues, want: true},
+		{filter: Filter{Logic: Or}, event: issues, want: false},
+		{filter: Filter{Logic: And, Conditions: conditions}, event: issues, want: true},
+		{filter: Filter{Logic: And, Conditions: conditions}, event: fork, want: false},
+		{filter: Filter{Logic: Or, Conditions: conditions}, event: issues, want: true},
+		{filter: Filter{Logic: Or, Conditions: conditions}, event: fork, want: true},
+		{filter: Filter{Logic: Or, Conditions: conditions}, event: watch, want: false},
+		{
+			// Funcs are always required
+			filter: Filter{
+				Logic:      Or,
+				Conditions: conditions,
+				Funcs:      []func(*github.Event) bool{func(*github.Event) bool { return false }},
+			},
+			event: issues,
+			want:  false,
+		},
+	}
+
+	for _, test := range tests {
+		have := test.filter.Matches(test.event)
+		if have != test.want {
+			t.Errorf("logic %v, event %v have: %v, want %v", test.filter.Logic, *test.event.Type, have, test.want)
+		}
+		have, err := test.filter.MatchesErr(test.event)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if have != test.want {
+			t.Errorf("MatchesErr logic %v, event %v have: %v, want %v", test.filter.Logic, *test.event.Type, have, test.want)
+		}
+	}
+
+	filter := Filter{Logic: Or, Conditions: []Condition{{Type: "IssuesEvent"}, {Type: "ForkEvent"}}}
+	if !filter.HandlesType("ForkEvent") {
+		t.Errorf("expected ForkEvent to be handled by or filter")
+	}
+	if filter.HandlesType("WatchEvent") {
+		t.Errorf("expected WatchEvent to not be handled by or filter")
+	}
+
+	data, err := json.Marshal(filter)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded Filter
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decoded.Logic != Or {
+		t.Errorf("decoded logic have: %v, want %v", decoded.Logic, Or)
+	}
+}
+
 func TestFilter_string(t *testing.T) {
 	tests := []struct {
 		Condition Condition
@@ -121,88 +426,3533 @@ func TestFilter_string(t *testing.T) {
 			Want:      `If event is not public`,
 		},
 		{
-			Condition: Condition{ComparePublic: true, Public: true, Negate: true},
-			Want:      `If event is not public`,
+			Condition: Condition{ComparePublic: true, Public: true, Negate: true},
+			Want:      `If event is not public`,
+		},
+		{
+			Condition: Condition{ComparePublic: true, Public: false, Negate: true},
+			Want:      `If event is public`,
+		},
+		{
+			Condition: Condition{OrganizationID: 1},
+			Want:      `If organization ID is 1`,
+		},
+		{
+			Condition: Condition{OrganizationID: 1, Negate: true},
+			Want:      `If organization ID is not 1`,
+		},
+		{
+			Condition: Condition{RepositoryID: 1},
+			Want:      `If repository ID is 1`,
+		},
+		{
+			Condition: Condition{RepositoryID: 1, Negate: true},
+			Want:      `If repository ID is not 1`,
+		},
+		{
+			Condition: Condition{PayloadReactionContent: "rocket"},
+			Want:      `If payload reaction content is "rocket"`,
+		},
+		{
+			Condition: Condition{PayloadReactionContent: "rocket", Negate: true},
+			Want:      `If payload reaction content is not "rocket"`,
+		},
+		{
+			Condition: Condition{PayloadCommitCommentPathRegexp: `\.go$`},
+			Want:      `If payload commit comment path matches regexp "\\.go$"`,
+		},
+		{
+			Condition: Condition{PayloadCommitCommentPathRegexp: `\.go$`, Negate: true},
+			Want:      `If payload commit comment path does not match regexp "\\.go$"`,
+		},
+		{
+			Condition: Condition{PayloadIssueAuthorAssociations: []string{"FIRST_TIMER", "MEMBER"}},
+			Want:      `If payload issue author association is any of ["FIRST_TIMER", "MEMBER"]`,
+		},
+		{
+			Condition: Condition{PayloadIssueAuthorAssociations: []string{"FIRST_TIMER"}, Negate: true},
+			Want:      `If payload issue author association is not any of ["FIRST_TIMER"]`,
+		},
+		{
+			Condition: Condition{PayloadSizeMin: 10000},
+			Want:      `If payload size is at least 10000 bytes`,
+		},
+		{
+			Condition: Condition{PayloadSizeMax: 100},
+			Want:      `If payload size is at most 100 bytes`,
+		},
+		{
+			Condition: Condition{PayloadSizeMin: 10, PayloadSizeMax: 100, Negate: true},
+			Want:      `If payload size is not at least 10 and at most 100 bytes`,
+		},
+		{
+			Condition: Condition{PayloadMembershipUserLogin: "octocat"},
+			Want:      `If payload membership user login is "octocat"`,
+		},
+		{
+			Condition: Condition{PayloadMembershipUserLogin: "octocat", Negate: true},
+			Want:      `If payload membership user login is not "octocat"`,
+		},
+		{
+			Condition: Condition{PayloadPullRequestMilestoneTitle: "foo"},
+			Want:      `If payload pull request milestone title is "foo"`,
+		},
+		{
+			Condition: Condition{PayloadPullRequestMilestoneTitle: "foo", Negate: true},
+			Want:      `If payload pull request milestone title is not "foo"`,
+		},
+		{
+			Condition: Condition{PayloadActionAny: []string{"opened", "reopened"}},
+			Want:      `If payload action is any of ["opened", "reopened"]`,
+		},
+		{
+			Condition: Condition{PayloadActionAny: []string{"opened", "reopened"}, Negate: true},
+			Want:      `If payload action is not any of ["opened", "reopened"]`,
+		},
+		{
+			Condition: Condition{PayloadRepositoryTopicsAny: []string{"go", "cli"}},
+			Want:      `If payload repository topics contain any of ["go", "cli"]`,
+		},
+		{
+			Condition: Condition{PayloadRepositoryTopicsAny: []string{"go"}, Negate: true},
+			Want:      `If payload repository topics do not contain any of ["go"]`,
+		},
+		{
+			Condition: Condition{Category: "comment"},
+			Want:      `If event category is "comment"`,
+		},
+		{
+			Condition: Condition{Category: "comment", Negate: true},
+			Want:      `If event category is not "comment"`,
+		},
+		{
+			Condition: Condition{PayloadHeadCommitAfter: time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)},
+			Want:      `If payload head commit after 2017-01-01T00:00:00Z`,
+		},
+		{
+			Condition: Condition{PayloadHeadCommitAfter: time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), PayloadHeadCommitBefore: time.Date(2017, 2, 1, 0, 0, 0, 0, time.UTC), Negate: true},
+			Want:      `If payload head commit not after 2017-01-01T00:00:00Z AND payload head commit not before 2017-02-01T00:00:00Z`,
+		},
+		{
+			Condition: Condition{ComparePushCreated: true, PushCreated: true},
+			Want:      `If payload push created a branch`,
+		},
+		{
+			Condition: Condition{ComparePushCreated: true, PushCreated: true, Negate: true},
+			Want:      `If payload push did not create a branch`,
+		},
+		{
+			Condition: Condition{ComparePushDeleted: true, PushDeleted: true},
+			Want:      `If payload push deleted a branch`,
+		},
+		{
+			Condition: Condition{ComparePushDeleted: true, PushDeleted: false},
+			Want:      `If payload push did not delete a branch`,
+		},
+		{
+			Condition: Condition{PayloadPushDistinctAuthorsMin: 2},
+			Want:      `If payload push has at least 2 distinct authors`,
+		},
+		{
+			Condition: Condition{PayloadPushDistinctAuthorsMin: 2, Negate: true},
+			Want:      `If payload push does not have at least 2 distinct authors`,
+		},
+		{
+			Condition: Condition{PayloadBodyMentions: "octocat"},
+			Want:      `If payload body mentions "@octocat"`,
+		},
+		{
+			Condition: Condition{PayloadBodyMentions: "@octocat", Negate: true},
+			Want:      `If payload body does not mention "@octocat"`,
+		},
+		{
+			Condition: Condition{PayloadSenderID: 583231},
+			Want:      `If payload sender ID is 583231`,
+		},
+		{
+			Condition: Condition{PayloadSenderID: 583231, Negate: true},
+			Want:      `If payload sender ID is not 583231`,
+		},
+		{
+			Condition: Condition{PayloadPullRequestIsCrossRepo: true},
+			Want:      `If payload pull request is cross-repository`,
+		},
+		{
+			Condition: Condition{PayloadPullRequestIsCrossRepo: true, Negate: true},
+			Want:      `If payload pull request is not cross-repository`,
+		},
+		{
+			Condition: Condition{CompareRepositoryArchived: true, RepositoryArchived: true},
+			Want:      `If payload repository is archived`,
+		},
+		{
+			Condition: Condition{CompareRepositoryArchived: true, RepositoryArchived: true, Negate: true},
+			Want:      `If payload repository is not archived`,
+		},
+		{
+			Condition: Condition{CompareRepositoryArchived: true, RepositoryArchived: false, Negate: true},
+			Want:      `If payload repository is archived`,
+		},
+		{
+			Condition: Condition{PayloadProjectNameRegexp: `^Roadmap`},
+			Want:      `If payload project name matches regexp "^Roadmap"`,
+		},
+		{
+			Condition: Condition{PayloadProjectNameRegexp: `^Roadmap`, Negate: true},
+			Want:      `If payload project name does not match regexp "^Roadmap"`,
+		},
+		{
+			Condition: Condition{PayloadPushTouchesPath: `^\.github/`},
+			Want:      `If payload push touches path matching "^\\.github/"`,
+		},
+		{
+			Condition: Condition{PayloadPushTouchesPath: `^\.github/`, Negate: true},
+			Want:      `If payload push does not touch path matching "^\\.github/"`,
+		},
+		{
+			Condition: Condition{PayloadLabelName: "triage"},
+			Want:      `If payload label name is "triage"`,
+		},
+		{
+			Condition: Condition{PayloadLabelName: "triage", Negate: true},
+			Want:      `If payload label name is not "triage"`,
+		},
+		{
+			Condition: Condition{MaxAge: time.Hour},
+			Want:      `If event age is at most 1h0m0s`,
+		},
+		{
+			Condition: Condition{MaxAge: time.Hour, Negate: true},
+			Want:      `If event age is not at most 1h0m0s`,
+		},
+		{
+			Condition: Condition{PayloadDeploymentStatusState: "failure"},
+			Want:      `If payload deployment status state is "failure"`,
+		},
+		{
+			Condition: Condition{PayloadDeploymentStatusState: "failure", Negate: true},
+			Want:      `If payload deployment status state is not "failure"`,
+		},
+		{
+			Condition: Condition{PayloadCommentCommand: "/deploy"},
+			Want:      `If payload comment command is "/deploy"`,
+		},
+		{
+			Condition: Condition{PayloadCommentCommand: "/deploy", Negate: true},
+			Want:      `If payload comment command is not "/deploy"`,
+		},
+		{
+			Condition: Condition{PayloadIssueMilestoneDueBefore: time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)},
+			Want:      `If payload issue milestone due before 2017-01-01T00:00:00Z`,
+		},
+		{
+			Condition: Condition{PayloadIssueMilestoneDueAfter: time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), Negate: true},
+			Want:      `If payload issue milestone due not after 2017-01-01T00:00:00Z`,
+		},
+		{
+			Condition: Condition{ActorAllowed: func(string) bool { return true }},
+			Want:      `If actor is allowed`,
+		},
+		{
+			Condition: Condition{ActorAllowed: func(string) bool { return true }, Negate: true},
+			Want:      `If actor is not allowed`,
+		},
+		{
+			Condition: Condition{PayloadPullRequestCommitsMin: 1},
+			Want:      `If payload pull request commits count is at least 1`,
+		},
+		{
+			Condition: Condition{PayloadPullRequestCommitsMin: 2, PayloadPullRequestCommitsMax: 10, Negate: true},
+			Want:      `If payload pull request commits count is not at least 2 and at most 10`,
+		},
+		{
+			Condition: Condition{PayloadIssueBodyHasTaskList: true},
+			Want:      `If payload issue body has a task list`,
+		},
+		{
+			Condition: Condition{PayloadIssueBodyHasTaskList: true, Negate: true},
+			Want:      `If payload issue body does not have a task list`,
+		},
+		{
+			Condition: Condition{PayloadPushFilesChangedMin: 1},
+			Want:      `If payload push files changed is at least 1`,
+		},
+		{
+			Condition: Condition{PayloadPushFilesChangedMax: 10, Negate: true},
+			Want:      `If payload push files changed is not at most 10`,
+		},
+		{
+			Condition: Condition{PayloadRepositoryStarsMin: 1000},
+			Want:      `If payload repository stars is at least 1000`,
+		},
+		{
+			Condition: Condition{PayloadRepositoryStarsMin: 1000, Negate: true},
+			Want:      `If payload repository stars is not at least 1000`,
+		},
+		{
+			Condition: Condition{CompareIssueLocked: true, IssueLocked: true},
+			Want:      `If payload issue is locked`,
+		},
+		{
+			Condition: Condition{CompareIssueLocked: true, IssueLocked: true, Negate: true},
+			Want:      `If payload issue is not locked`,
+		},
+		{
+			Condition: Condition{PayloadCommentWasEdited: true},
+			Want:      `If payload comment was edited`,
+		},
+		{
+			Condition: Condition{PayloadCommentWasEdited: true, Negate: true},
+			Want:      `If payload comment was not edited`,
+		},
+		{
+			Condition: Condition{ActorLogin: "bradleyfalzon"},
+			Want:      `If actor login is "bradleyfalzon"`,
+		},
+		{
+			Condition: Condition{ActorLogin: "bradleyfalzon", Negate: true},
+			Want:      `If actor login is not "bradleyfalzon"`,
+		},
+		{
+			Condition: Condition{ActorID: 1},
+			Want:      `If actor ID is 1`,
+		},
+		{
+			Condition: Condition{ActorID: 1, Negate: true},
+			Want:      `If actor ID is not 1`,
+		},
+		{
+			Condition: Condition{PayloadPushIsTag: true},
+			Want:      `If payload push is to a tag`,
+		},
+		{
+			Condition: Condition{PayloadPushIsTag: true, Negate: true},
+			Want:      `If payload push is not to a tag`,
+		},
+		{
+			Condition: Condition{BusinessHours: &BusinessHours{Start: 9, End: 17}},
+			Want:      `If event created between 09:00 and 17:00 UTC`,
+		},
+		{
+			Condition: Condition{BusinessHours: &BusinessHours{Start: 9, End: 17}, Negate: true},
+			Want:      `If event not created between 09:00 and 17:00 UTC`,
+		},
+		{
+			Condition: Condition{RepositoryName: "owner/repo"},
+			Want:      `If repository name is "owner/repo"`,
+		},
+		{
+			Condition: Condition{RepositoryName: "owner/repo", Negate: true},
+			Want:      `If repository name is not "owner/repo"`,
+		},
+		{
+			Condition: Condition{PayloadWorkflowJobConclusion: "failure"},
+			Want:      `If payload workflow job conclusion is "failure"`,
+		},
+		{
+			Condition: Condition{PayloadWorkflowJobConclusion: "failure", Negate: true},
+			Want:      `If payload workflow job conclusion is not "failure"`,
+		},
+		{
+			Condition: Condition{RepositoryNameGlob: "myorg/*"},
+			Want:      `If repository name matches glob "myorg/*"`,
+		},
+		{
+			Condition: Condition{RepositoryNameGlob: "myorg/*", Negate: true},
+			Want:      `If repository name does not match glob "myorg/*"`,
+		},
+		{
+			Condition: Condition{PayloadWorkflowJobLabelsAny: []string{"self-hosted", "linux"}},
+			Want:      `If payload workflow job labels contain any of ["self-hosted", "linux"]`,
+		},
+		{
+			Condition: Condition{PayloadWorkflowJobLabelsAny: []string{"self-hosted"}, Negate: true},
+			Want:      `If payload workflow job labels do not contain any of ["self-hosted"]`,
+		},
+		{
+			Condition: Condition{PayloadActionTerminal: true},
+			Want:      `If payload action is terminal`,
+		},
+		{
+			Condition: Condition{PayloadActionOpening: true, Negate: true},
+			Want:      `If payload action is not opening`,
+		},
+		{
+			Condition: Condition{PayloadCommentLengthMin: 1, PayloadCommentLengthMax: 280},
+			Want:      `If payload comment length is at least 1 and at most 280`,
+		},
+		{
+			Condition: Condition{PayloadCommentLengthMax: 280, Negate: true},
+			Want:      `If payload comment length is not at most 280`,
+		},
+		{
+			Condition: Condition{PayloadURLHostRegexp: `^github\.com$`},
+			Want:      `If payload URL host matches regexp "^github\\.com$"`,
+		},
+		{
+			Condition: Condition{PayloadURLHostRegexp: `^github\.com$`, Negate: true},
+			Want:      `If payload URL host does not match regexp "^github\\.com$"`,
+		},
+		{
+			Condition: Condition{PayloadPullRequestAction: "closed"},
+			Want:      `If payload pull request action is "closed"`,
+		},
+		{
+			Condition: Condition{PayloadPullRequestMerged: github.Bool(true)},
+			Want:      `If payload pull request is merged`,
+		},
+		{
+			Condition: Condition{PayloadPullRequestMerged: github.Bool(true), Negate: true},
+			Want:      `If payload pull request is not merged`,
+		},
+		{
+			Condition: Condition{PayloadPullRequestMerged: github.Bool(false)},
+			Want:      `If payload pull request is not merged`,
+		},
+		{
+			Condition: Condition{PayloadPullRequestBaseRef: "main"},
+			Want:      `If payload pull request base ref is "main"`,
+		},
+		{
+			Condition: Condition{PayloadIssueTransferred: true},
+			Want:      `If payload issue was transferred`,
+		},
+		{
+			Condition: Condition{PayloadIssueTransferred: true, Negate: true},
+			Want:      `If payload issue was not transferred`,
+		},
+		{
+			Condition: Condition{PayloadJSONPathBool: &JSONPathBool{Path: "pull_request.draft", Value: true}},
+			Want:      `If payload path "pull_request.draft" is true`,
+		},
+		{
+			Condition: Condition{PayloadJSONPathBool: &JSONPathBool{Path: "pull_request.draft"}, Negate: true},
+			Want:      `If payload path "pull_request.draft" is not false`,
+		},
+		{
+			Condition: Condition{PayloadPullRequestHeadRefRegexp: `^release/`},
+			Want:      `If payload pull request head ref matches regexp "^release/"`,
+		},
+		{
+			Condition: Condition{PayloadRef: "refs/heads/main"},
+			Want:      `If payload ref is "refs/heads/main"`,
+		},
+		{
+			Condition: Condition{PayloadRefRegexp: `^refs/heads/`, Negate: true},
+			Want:      `If payload ref does not match regexp "^refs/heads/"`,
+		},
+		{
+			Condition: Condition{PayloadCommitsCountMin: 2, PayloadCommitsCountMax: 5},
+			Want:      `If payload commits count is at least 2 and at most 5`,
+		},
+		{
+			Condition: Condition{PayloadCommitsCountMin: 6, Negate: true},
+			Want:      `If payload commits count is not at least 6`,
+		},
+		{
+			Condition: Condition{PayloadCommitMessageRegexp: `(?i)hotfix`},
+			Want:      `If payload commit message matches regexp "(?i)hotfix"`,
+		},
+		{
+			Condition: Condition{PayloadCommentBodyRegexp: `^/deploy\b`},
+			Want:      `If payload comment body matches regexp "^/deploy\\b"`,
+		},
+		{
+			Condition: Condition{PayloadSenderIsRepoOwner: true},
+			Want:      `If payload sender is the repository owner`,
+		},
+		{
+			Condition: Condition{PayloadSenderIsRepoOwner: true, Negate: true},
+			Want:      `If payload sender is not the repository owner`,
+		},
+		{
+			Condition: Condition{PayloadPackageEcosystem: "npm"},
+			Want:      `If payload package ecosystem is "npm"`,
+		},
+		{
+			Condition: Condition{PayloadIssueNumber: 42},
+			Want:      `If payload issue number is 42`,
+		},
+		{
+			Condition: Condition{PayloadIssueNumber: 42, Negate: true},
+			Want:      `If payload issue number is not 42`,
+		},
+		{
+			Condition: Condition{PayloadIssueState: "closed"},
+			Want:      `If payload issue state is "closed"`,
+		},
+		{
+			Condition: Condition{PayloadIssueState: "closed", Negate: true},
+			Want:      `If payload issue state is not "closed"`,
+		},
+		{
+			Condition: Condition{PayloadSampleRate: 0.1},
+			Want:      `If event is in the 10% sample`,
+		},
+		{
+			Condition: Condition{PayloadSampleRate: 0.125, Negate: true},
+			Want:      `If event is not in the 12.5% sample`,
+		},
+		{
+			Condition: Condition{PayloadRepositorySizeMin: 100000},
+			Want:      `If payload repository size is at least 100000`,
+		},
+		{
+			Condition: Condition{PayloadRepositorySizeMin: 100, PayloadRepositorySizeMax: 100000, Negate: true},
+			Want:      `If payload repository size is not at least 100 and at most 100000`,
+		},
+		{
+			Condition: Condition{PayloadIssueLabels: []string{"bug", "priority"}},
+			Want:      `If payload issue labels contain all of ["bug", "priority"]`,
+		},
+		{
+			Condition: Condition{PayloadIssueLabels: []string{"bug", "priority"}, Negate: true},
+			Want:      `If payload issue labels do not contain all of ["bug", "priority"]`,
+		},
+		{
+			Condition: Condition{Types: []string{"IssuesEvent", "PullRequestEvent"}},
+			Want:      `If type is any of ["IssuesEvent", "PullRequestEvent"]`,
+		},
+		{
+			Condition: Condition{Type: "PushEvent", Types: []string{"IssuesEvent"}, Negate: true},
+			Want:      `If type is not any of ["PushEvent", "IssuesEvent"]`,
+		},
+		{
+			Condition: Condition{PayloadSecurityAdvisorySeverity: "critical"},
+			Want:      `If payload security advisory severity is "critical"`,
+		},
+		{
+			Condition: Condition{PayloadHasNoAction: true},
+			Want:      `If payload has no action`,
+		},
+		{
+			Condition: Condition{PayloadHasNoAction: true, Negate: true},
+			Want:      `If payload has an action`,
+		},
+		{
+			Condition: Condition{CreatedAfter: time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), CreatedBefore: time.Date(2017, 2, 1, 0, 0, 0, 0, time.UTC)},
+			Want:      `If event created after 2017-01-01T00:00:00Z AND event created before 2017-02-01T00:00:00Z`,
+		},
+		{
+			Condition: Condition{CreatedAfter: time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), Negate: true},
+			Want:      `If event created not after 2017-01-01T00:00:00Z`,
+		},
+		{
+			Condition: Condition{PayloadPullRequestRequestedReviewer: "octocat"},
+			Want:      `If payload pull request requested reviewer is "octocat"`,
+		},
+		{
+			Condition: Condition{PayloadPullRequestRequestedReviewer: "octocat", Negate: true},
+			Want:      `If payload pull request requested reviewer is not "octocat"`,
+		},
+	}
+
+	for _, test := range tests {
+		if have := test.Condition.String(); have != test.Want {
+			t.Errorf("String does not match\nhave: %v\nwant: %v", have, test.Want)
+		}
+	}
+
+}
+
+func TestFilter_stringConditions(t *testing.T) {
+	always := func(*github.Event) bool { return true }
+
+	tests := []struct {
+		filter Filter
+		want   string
+	}{
+		{Filter{}, "Match all events"},
+		{Filter{Conditions: []Condition{{}}}, "Match all events"},
+		{Filter{Logic: Or}, "Match no events"},
+		{
+			Filter{Conditions: []Condition{{Type: "IssuesEvent"}}},
+			`If type is "IssuesEvent"`,
+		},
+		{
+			Filter{Conditions: []Condition{{Type: "IssuesEvent"}, {PayloadAction: "opened"}}},
+			`If (type is "IssuesEvent") AND (payload action is "opened")`,
+		},
+		{
+			Filter{Conditions: []Condition{{Type: "IssuesEvent", PayloadAction: "opened"}, {RepositoryID: 1, Negate: true}}},
+			`If (type is "IssuesEvent" AND payload action is "opened") AND (repository ID is not 1)`,
+		},
+		{
+			Filter{Conditions: []Condition{{Type: "IssuesEvent"}, {Type: "PushEvent"}}, Logic: Or},
+			`If (type is "IssuesEvent") OR (type is "PushEvent")`,
+		},
+		{
+			Filter{Conditions: []Condition{{Type: "IssuesEvent"}, {}}, Logic: Or},
+			"Match all events",
+		},
+		{
+			Filter{
+				Conditions:   []Condition{{Type: "IssuesEvent"}, {Type: "PushEvent"}},
+				Logic:        Or,
+				Root:         NewOr(&Condition{PayloadAction: "opened"}, &Condition{PayloadAction: "closed"}),
+				Funcs:        []func(*github.Event) bool{always},
+				ExcludeTypes: []string{"WatchEvent"},
+			},
+			`If (type is not any of ["WatchEvent"]) AND ((type is "IssuesEvent") OR (type is "PushEvent")) AND ` +
+				`((payload action is "opened") OR (payload action is "closed")) AND (all custom funcs pass)`,
+		},
+	}
+
+	for _, test := range tests {
+		if have := test.filter.String(); have != test.want {
+			t.Errorf("String does not match\nhave: %v\nwant: %v", have, test.want)
+		}
+	}
+}
+
+func TestCondition_type(t *testing.T) {
+	events := []*github.Event{
+		{
+			Type: github.String("IssuesEvent"),
+		},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{Type: "NonExistentEvent"},
+			Want:      nil,
+		},
+		{
+			Condition: Condition{Type: "IssuesEvent"},
+			Want:      events[0],
+		},
+		{
+			Condition: Condition{Type: "NonExistentEvent", Negate: true},
+			Want:      events[0],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_types(t *testing.T) {
+	events := []*github.Event{
+		{Type: github.String("IssuesEvent")},
+		{Type: github.String("PullRequestEvent")},
+		{Type: github.String("PushEvent")},
+		{Type: nil},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      []*github.Event
+	}{
+		{
+			Condition: Condition{Types: []string{"IssuesEvent", "PullRequestEvent"}},
+			Want:      events[:2],
+		},
+		{
+			Condition: Condition{Types: []string{"IssuesEvent", "PullRequestEvent"}, Negate: true},
+			Want:      events[2:],
+		},
+		{
+			Condition: Condition{Type: "PushEvent", Types: []string{"IssuesEvent"}},
+			Want:      []*github.Event{events[0], events[2]},
+		},
+		{
+			Condition: Condition{Types: []string{"NonExistentEvent"}},
+			Want:      nil,
+		},
+	}
+
+	for _, test := range tests {
+		var have []*github.Event
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				have = append(have, event)
+			}
+		}
+		if !reflect.DeepEqual(have, test.Want) {
+			t.Errorf("condition %+v matched %v events, want %v", test.Condition, len(have), len(test.Want))
+		}
+	}
+}
+
+func TestCondition_matchesIgnoringType(t *testing.T) {
+	var (
+		opened = json.RawMessage(`{"action":"opened"}`)
+		closed = json.RawMessage(`{"action":"closed"}`)
+	)
+
+	events := []*github.Event{
+		{Type: github.String("IssuesEvent"), RawPayload: &opened},
+		{Type: github.String("PullRequestEvent"), RawPayload: &opened},
+		{Type: github.String("IssuesEvent"), RawPayload: &closed},
+		{Type: github.String("PullRequestEvent"), RawPayload: &closed},
+		{Type: github.String("IssuesEvent"), RawPayload: nil},
+	}
+
+	tests := []struct {
+		condition Condition
+		want      []bool
+	}{
+		{Condition{PayloadAction: "opened"}, []bool{true, true, false, false, false}},
+		{Condition{Type: "IssuesEvent", PayloadAction: "opened"}, []bool{true, true, false, false, false}},
+		{Condition{Types: []string{"PushEvent"}, PayloadAction: "opened"}, []bool{true, true, false, false, false}},
+		{Condition{Type: "IssuesEvent", PayloadAction: "opened", Negate: true}, []bool{false, false, true, true, false}},
+		{Condition{Category: "issue", PayloadAction: "opened"}, []bool{true, false, false, false, false}},
+	}
+
+	for _, test := range tests {
+		untyped := test.condition
+		untyped.Type, untyped.Types = "", nil
+		for i, event := range events {
+			have := test.condition.MatchesIgnoringType(event)
+			if have != test.want[i] {
+				t.Errorf("condition %v event %d have: %v, want: %v", test.condition, i, have, test.want[i])
+			}
+			if want := untyped.Matches(event); have != want {
+				t.Errorf("condition %v event %d have: %v, want same as Matches without type: %v", test.condition, i, have, want)
+			}
+		}
+	}
+
+	// Matches still tests the type after MatchesIgnoringType
+	condition := Condition{Type: "IssuesEvent", PayloadAction: "opened"}
+	condition.MatchesIgnoringType(events[1])
+	if condition.Matches(events[1]) {
+		t.Errorf("condition %v matched event of another type", condition)
+	}
+}
+
+func TestCondition_payloadAction(t *testing.T) {
+	var (
+		opened  = json.RawMessage(`{"action":"opened"}`)
+		created = json.RawMessage(`{"action":"created"}`)
+	)
+
+	events := []*github.Event{
+		{RawPayload: &opened},
+		{RawPayload: &created},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadAction: "opened"},
+			Want:      events[0],
+		},
+		{
+			Condition: Condition{PayloadAction: "CREATED"},
+			Want:      events[1],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadIssueLabel(t *testing.T) {
+	var (
+		empty    = json.RawMessage(`{"issue":{"labels":[]}}`)
+		contains = json.RawMessage(`{"issue":{"labels":["LBL", "x"]}}`)
+	)
+
+	events := []*github.Event{
+		{RawPayload: &empty},
+		{RawPayload: &contains},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadIssueLabel: "nomatch"},
+			Want:      nil,
+		},
+		{
+			Condition: Condition{PayloadIssueLabel: "lbl"},
+			Want:      events[1],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadIssueMilestoneTitle(t *testing.T) {
+	var (
+		empty    = json.RawMessage(`{"issue":{"milestone":null}}`)
+		contains = json.RawMessage(`{"issue":{"milestone":{"Title":"title"}}}`)
+	)
+
+	events := []*github.Event{
+		{RawPayload: &empty},
+		{RawPayload: &contains},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadIssueMilestoneTitle: "nomatch"},
+			Want:      nil,
+		},
+		{
+			Condition: Condition{PayloadIssueMilestoneTitle: "title"},
+			Want:      events[1],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadIssueTitleRegexp(t *testing.T) {
+	var (
+		match   = json.RawMessage(`{"issue":{"title":"This will Match"}}`)
+		nomatch = json.RawMessage(`{"issue":{"title":"This will Not Match"}}`)
+	)
+
+	events := []*github.Event{
+		{RawPayload: &match},
+		{RawPayload: &nomatch},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadIssueTitleRegexp: "not a match"},
+			Want:      nil,
+		},
+		{
+			Condition: Condition{PayloadIssueTitleRegexp: `(?i)will\s+match`},
+			Want:      events[0],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadIssueBodyRegexp(t *testing.T) {
+	var (
+		match   = json.RawMessage(`{"issue":{"body":"This will Match"}}`)
+		nomatch = json.RawMessage(`{"issue":{"body":"This will Not Match"}}`)
+	)
+
+	events := []*github.Event{
+		{RawPayload: &match},
+		{RawPayload: &nomatch},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadIssueBodyRegexp: "not a match"},
+			Want:      nil,
+		},
+		{
+			Condition: Condition{PayloadIssueBodyRegexp: `(?i)will\s+match`},
+			Want:      events[0],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_public(t *testing.T) {
+	events := []*github.Event{
+		{Public: github.Bool(true)},
+		{Public: github.Bool(false)},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{ComparePublic: true, Public: true},
+			Want:      events[0],
+		},
+		{
+			Condition: Condition{ComparePublic: true, Public: false},
+			Want:      events[1],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_organizationID(t *testing.T) {
+	events := []*github.Event{
+		{Org: nil},
+		{Org: &github.Organization{ID: github.Int(1)}},
+		{Org: &github.Organization{ID: github.Int(2)}},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{OrganizationID: 1},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{OrganizationID: 2},
+			Want:      events[2],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_repositoryID(t *testing.T) {
+	events := []*github.Event{
+		{Repo: nil},
+		{Repo: &github.Repository{ID: github.Int(1)}},
+		{Repo: &github.Repository{ID: github.Int(2)}},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{RepositoryID: 1},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{RepositoryID: 2},
+			Want:      events[2],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadReactionContent(t *testing.T) {
+	var (
+		rocket = json.RawMessage(`{"action":"created","reaction":{"content":"rocket"}}`)
+		plus   = json.RawMessage(`{"action":"created","reaction":{"content":"+1"}}`)
+	)
+
+	events := []*github.Event{
+		{RawPayload: nil},
+		{RawPayload: &rocket},
+		{RawPayload: &plus},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadReactionContent: "rocket"},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{PayloadReactionContent: "+1"},
+			Want:      events[2],
+		},
+		{
+			Condition: Condition{PayloadReactionContent: "ROCKET"},
+			Want:      nil,
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadCommitCommentPathRegexp(t *testing.T) {
+	var (
+		match   = json.RawMessage(`{"action":"created","comment":{"path":"cmd/main.go","position":4}}`)
+		nomatch = json.RawMessage(`{"action":"created","comment":{"path":"README.md","position":1}}`)
+	)
+
+	events := []*github.Event{
+		{RawPayload: nil},
+		{RawPayload: &match},
+		{RawPayload: &nomatch},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadCommitCommentPathRegexp: `\.go$`},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{PayloadCommitCommentPathRegexp: `^docs/`},
+			Want:      nil,
+		},
+		{
+			Condition: Condition{PayloadCommitCommentPathRegexp: `(`},
+			Want:      nil,
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadIssueAuthorAssociations(t *testing.T) {
+	var (
+		firstTimer = json.RawMessage(`{"issue":{"author_association":"FIRST_TIMER"}}`)
+		member     = json.RawMessage(`{"issue":{"author_association":"MEMBER"}}`)
+	)
+
+	events := []*github.Event{
+		{RawPayload: nil},
+		{RawPayload: &firstTimer},
+		{RawPayload: &member},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadIssueAuthorAssociations: []string{"first_timer", "FIRST_TIME_CONTRIBUTOR"}},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{PayloadIssueAuthorAssociations: []string{"OWNER"}},
+			Want:      nil,
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadSize(t *testing.T) {
+	var (
+		small = json.RawMessage(`{}`)                  // 2 bytes
+		large = json.RawMessage(`{"action":"opened"}`) // 19 bytes
+	)
+
+	events := []*github.Event{
+		{RawPayload: nil},
+		{RawPayload: &small},
+		{RawPayload: &large},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadSizeMin: 19},
+			Want:      events[2],
+		},
+		{
+			Condition: Condition{PayloadSizeMin: 20},
+			Want:      nil,
+		},
+		{
+			Condition: Condition{PayloadSizeMax: 2},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{PayloadSizeMin: 3, PayloadSizeMax: 19},
+			Want:      events[2],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadMembershipUserLogin(t *testing.T) {
+	var (
+		added   = json.RawMessage(`{"action":"member_added","membership":{"state":"active","role":"member","user":{"login":"Octocat"}}}`)
+		removed = json.RawMessage(`{"action":"member_removed","membership":{"state":"active","role":"member","user":{"login":"hubot"}}}`)
+	)
+
+	events := []*github.Event{
+		{RawPayload: nil},
+		{RawPayload: &added},
+		{RawPayload: &removed},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadMembershipUserLogin: "octocat"},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{PayloadMembershipUserLogin: "nomatch"},
+			Want:      nil,
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadPullRequestMilestoneTitle(t *testing.T) {
+	var (
+		empty    = json.RawMessage(`{"pull_request":{"milestone":null}}`)
+		contains = json.RawMessage(`{"pull_request":{"milestone":{"title":"v1.0"}}}`)
+	)
+
+	events := []*github.Event{
+		{RawPayload: nil},
+		{RawPayload: &empty},
+		{RawPayload: &contains},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadPullRequestMilestoneTitle: "nomatch"},
+			Want:      nil,
+		},
+		{
+			Condition: Condition{PayloadPullRequestMilestoneTitle: "V1.0"},
+			Want:      events[2],
+		},
+		{
+			// Absent milestone continues to not match when negated
+			Condition: Condition{PayloadPullRequestMilestoneTitle: "nomatch", Negate: true},
+			Want:      events[2],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadActionAny(t *testing.T) {
+	var (
+		opened = json.RawMessage(`{"action":"opened"}`)
+		closed = json.RawMessage(`{"action":"closed"}`)
+	)
+
+	events := []*github.Event{
+		{RawPayload: nil},
+		{RawPayload: &opened},
+		{RawPayload: &closed},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadActionAny: []string{"OPENED", "reopened"}},
+			Want:      events[1],
+		},
+		{
+			// Action in list when negated does not match
+			Condition: Condition{PayloadActionAny: []string{"opened", "reopened"}, Negate: true},
+			Want:      events[2],
+		},
+		{
+			// Action not in list when negated matches
+			Condition: Condition{PayloadActionAny: []string{"closed"}, Negate: true},
+			Want:      events[1],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadRepositoryTopicsAny(t *testing.T) {
+	var (
+		absent = json.RawMessage(`{"repository":{"full_name":"owner/repo"}}`)
+		topics = json.RawMessage(`{"repository":{"full_name":"owner/repo","topics":["Go","github"]}}`)
+	)
+
+	events := []*github.Event{
+		{RawPayload: nil},
+		{RawPayload: &absent},
+		{RawPayload: &topics},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadRepositoryTopicsAny: []string{"go", "rust"}},
+			Want:      events[2],
+		},
+		{
+			Condition: Condition{PayloadRepositoryTopicsAny: []string{"rust"}},
+			Want:      nil,
+		},
+		{
+			// Absent topics continues to not match when negated
+			Condition: Condition{PayloadRepositoryTopicsAny: []string{"rust"}, Negate: true},
+			Want:      events[2],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_category(t *testing.T) {
+	events := []*github.Event{
+		{Type: github.String("IssueCommentEvent")},
+		{Type: github.String("CommitCommentEvent")},
+		{Type: github.String("PullRequestReviewCommentEvent")},
+		{Type: github.String("IssuesEvent")},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      []*github.Event
+	}{
+		{
+			Condition: Condition{Category: "comment"},
+			Want:      events[:3],
+		},
+		{
+			Condition: Condition{Category: "comment", Negate: true},
+			Want:      events[3:],
+		},
+		{
+			Condition: Condition{Category: "unknown"},
+			Want:      nil,
+		},
+	}
+
+	for _, test := range tests {
+		var have []*github.Event
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				have = append(have, event)
+			}
+		}
+		if !reflect.DeepEqual(have, test.Want) {
+			t.Errorf("condition %+v matched %v events, want %v", test.Condition, len(have), len(test.Want))
+		}
+	}
+}
+
+func TestCondition_payloadHeadCommit(t *testing.T) {
+	var (
+		january  = json.RawMessage(`{"ref":"refs/heads/master","head_commit":{"timestamp":"2017-01-15T10:00:00+10:00"}}`)
+		march    = json.RawMessage(`{"ref":"refs/heads/master","head_commit":{"timestamp":"2017-03-15T10:00:00Z"}}`)
+		invalid  = json.RawMessage(`{"ref":"refs/heads/master","head_commit":{"timestamp":"yesterday"}}`)
+		noCommit = json.RawMessage(`{"ref":"refs/heads/master","head_commit":null}`)
+	)
+
+	events := []*github.Event{
+		{RawPayload: nil},
+		{RawPayload: &january},
+		{RawPayload: &march},
+		{RawPayload: &invalid},
+		{RawPayload: &noCommit},
+	}
+
+	var (
+		jan1 = time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+		feb1 = time.Date(2017, 2, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadHeadCommitAfter: jan1, PayloadHeadCommitBefore: feb1},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{PayloadHeadCommitAfter: feb1},
+			Want:      events[2],
+		},
+		{
+			Condition: Condition{PayloadHeadCommitBefore: jan1},
+			Want:      nil,
+		},
+		{
+			Condition: Condition{PayloadHeadCommitAfter: jan1, PayloadHeadCommitBefore: feb1, Negate: true},
+			Want:      events[2],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_pushCreatedDeleted(t *testing.T) {
+	var (
+		created = json.RawMessage(`{"ref":"refs/heads/feature","created":true,"deleted":false}`)
+		deleted = json.RawMessage(`{"ref":"refs/heads/feature","created":false,"deleted":true}`)
+		missing = json.RawMessage(`{"ref":"refs/heads/feature"}`)
+	)
+
+	events := []*github.Event{
+		{RawPayload: nil},
+		{RawPayload: &created},
+		{RawPayload: &deleted},
+		{RawPayload: &missing},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      []*github.Event
+	}{
+		{
+			Condition: Condition{ComparePushCreated: true, PushCreated: true},
+			Want:      []*github.Event{events[1]},
+		},
+		{
+			Condition: Condition{ComparePushDeleted: true, PushDeleted: true},
+			Want:      []*github.Event{events[2]},
+		},
+		{
+			Condition: Condition{ComparePushCreated: true, PushCreated: false, ComparePushDeleted: true, PushDeleted: false},
+			Want:      []*github.Event{events[3]},
+		},
+	}
+
+	for _, test := range tests {
+		var have []*github.Event
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				have = append(have, event)
+			}
+		}
+		if !reflect.DeepEqual(have, test.Want) {
+			t.Errorf("condition %+v matched %v events, want %v", test.Condition, len(have), len(test.Want))
+		}
+	}
+}
+
+func TestCondition_payloadPushDistinctAuthorsMin(t *testing.T) {
+	var (
+		solo = json.RawMessage(`{"ref":"refs/heads/master","commits":[
+			{"message":"one","author":{"email":"a@example.com"}},
+			{"message":"two","author":{"email":"a@example.com"}}
+		]}`)
+		collaborative = json.RawMessage(`{"ref":"refs/heads/master","commits":[
+			{"message":"one","author":{"email":"a@example.com"}},
+			{"message":"two","author":{"email":"b@example.com"}},
+			{"message":"three","author":{"email":"a@example.com"}}
+		]}`)
+	)
+
+	events := []*github.Event{
+		{RawPayload: nil},
+		{RawPayload: &solo},
+		{RawPayload: &collaborative},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadPushDistinctAuthorsMin: 2},
+			Want:      events[2],
+		},
+		{
+			Condition: Condition{PayloadPushDistinctAuthorsMin: 3},
+			Want:      nil,
+		},
+		{
+			Condition: Condition{PayloadPushDistinctAuthorsMin: 2, Negate: true},
+			Want:      events[1],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadBodyMentions(t *testing.T) {
+	var (
+		issue        = json.RawMessage(`{"action":"opened","issue":{"body":"cc @OctoCat, thoughts?"}}`)
+		issuePartial = json.RawMessage(`{"action":"opened","issue":{"body":"cc @octocat-bot and me@octocat.com"}}`)
+		comment      = json.RawMessage(`{"action":"created","issue":{"body":"unrelated"},"comment":{"body":"@octocat"}}`)
+	)
+
+	events := []*github.Event{
+		{Type: github.String("IssuesEvent"), RawPayload: nil},
+		{Type: github.String("IssuesEvent"), RawPayload: &issue},
+		{Type: github.String("IssuesEvent"), RawPayload: &issuePartial},
+		{Type: github.String("IssueCommentEvent"), RawPayload: &comment},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      []*github.Event
+	}{
+		{
+			Condition: Condition{PayloadBodyMentions: "octocat"},
+			Want:      []*github.Event{events[1], events[3]},
+		},
+		{
+			Condition: Condition{PayloadBodyMentions: "@octocat", Negate: true},
+			Want:      []*github.Event{events[2]},
+		},
+		{
+			Condition: Condition{PayloadBodyMentions: "octocat-bot"},
+			Want:      []*github.Event{events[2]},
+		},
+	}
+
+	for _, test := range tests {
+		var have []*github.Event
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				have = append(have, event)
+			}
+		}
+		if !reflect.DeepEqual(have, test.Want) {
+			t.Errorf("condition %+v matched %v events, want %v", test.Condition, len(have), len(test.Want))
+		}
+	}
+
+	// Modifying Categories must not change which body is inspected
+	defer func(comment []string) { Categories["comment"] = comment }(Categories["comment"])
+	Categories["comment"] = nil
+	if !(&Condition{PayloadBodyMentions: "octocat"}).Matches(events[3]) {
+		t.Errorf("expected comment body to be inspected after modifying Categories")
+	}
+}
+
+func TestCondition_payloadSenderID(t *testing.T) {
+	var (
+		octocat = json.RawMessage(`{"action":"opened","sender":{"login":"octocat","id":583231}}`)
+		hubot   = json.RawMessage(`{"action":"opened","sender":{"login":"hubot","id":480938}}`)
+	)
+
+	events := []*github.Event{
+		{RawPayload: nil},
+		{RawPayload: &octocat},
+		{RawPayload: &hubot},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadSenderID: 583231},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{PayloadSenderID: 1},
+			Want:      nil,
+		},
+		{
+			Condition: Condition{PayloadSenderID: 583231, Negate: true},
+			Want:      events[2],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadPullRequestIsCrossRepo(t *testing.T) {
+	var (
+		same = json.RawMessage(`{"action":"opened","pull_request":{
+			"head":{"ref":"feature","repo":{"full_name":"owner/repo"}},
+			"base":{"ref":"master","repo":{"full_name":"owner/repo"}}
+		}}`)
+		fork = json.RawMessage(`{"action":"opened","pull_request":{
+			"head":{"ref":"feature","repo":{"full_name":"contributor/repo"}},
+			"base":{"ref":"master","repo":{"full_name":"owner/repo"}}
+		}}`)
+		notPR = json.RawMessage(`{"action":"opened","issue":{}}`)
+	)
+
+	events := []*github.Event{
+		{RawPayload: nil},
+		{RawPayload: &same},
+		{RawPayload: &fork},
+		{RawPayload: &notPR},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadPullRequestIsCrossRepo: true},
+			Want:      events[2],
+		},
+		{
+			Condition: Condition{PayloadPullRequestIsCrossRepo: true, Negate: true},
+			Want:      events[1],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_repositoryArchived(t *testing.T) {
+	var (
+		archived = json.RawMessage(`{"action":"opened","repository":{"full_name":"owner/repo","archived":true}}`)
+		active   = json.RawMessage(`{"action":"opened","repository":{"full_name":"owner/repo","archived":false}}`)
+	)
+
+	events := []*github.Event{
+		{RawPayload: nil},
+		{RawPayload: &archived},
+		{RawPayload: &active},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{CompareRepositoryArchived: true, RepositoryArchived: true},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{CompareRepositoryArchived: true, RepositoryArchived: false},
+			Want:      events[2],
+		},
+		{
+			Condition: Condition{CompareRepositoryArchived: true, RepositoryArchived: true, Negate: true},
+			Want:      events[2],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadProjectNameRegexp(t *testing.T) {
+	var (
+		match   = json.RawMessage(`{"action":"created","project":{"name":"Roadmap 2017","state":"open"}}`)
+		nomatch = json.RawMessage(`{"action":"created","project":{"name":"Triage","state":"open"}}`)
+	)
+
+	events := []*github.Event{
+		{Type: github.String("ProjectEvent"), RawPayload: nil},
+		{Type: github.String("ProjectEvent"), RawPayload: &match},
+		{Type: github.String("ProjectEvent"), RawPayload: &nomatch},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadProjectNameRegexp: `^Roadmap \d+$`},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{PayloadProjectNameRegexp: `^Roadmap`, Negate: true},
+			Want:      events[2],
+		},
+		{
+			Condition: Condition{PayloadProjectNameRegexp: `(`},
+			Want:      nil,
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadPushTouchesPath(t *testing.T) {
+	var (
+		codeowners = json.RawMessage(`{"ref":"refs/heads/master","commits":[
+			{"added":["main.go"],"modified":[],"removed":[]},
+			{"added":[],"modified":[".github/CODEOWNERS"],"removed":[]}
+		]}`)
+		docs = json.RawMessage(`{"ref":"refs/heads/master","commits":[
+			{"added":["docs/intro.md"],"modified":["README.md"],"removed":["docs/old.md"]}
+		]}`)
+	)
+
+	events := []*github.Event{
+		{RawPayload: nil},
+		{RawPayload: &codeowners},
+		{RawPayload: &docs},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadPushTouchesPath: `(^|/)CODEOWNERS$`},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{PayloadPushTouchesPath: `^docs/old\.md$`},
+			Want:      events[2],
+		},
+		{
+			Condition: Condition{PayloadPushTouchesPath: `(^|/)CODEOWNERS$`, Negate: true},
+			Want:      events[2],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadLabelName(t *testing.T) {
+	var (
+		triage = json.RawMessage(`{"action":"labeled","label":{"name":"Triage"},"issue":{"labels":["bug","Triage"]}}`)
+		bug    = json.RawMessage(`{"action":"labeled","label":{"name":"bug"},"issue":{"labels":["bug","Triage"]}}`)
+	)
+
+	events := []*github.Event{
+		{Type: github.String("IssuesEvent"), RawPayload: nil},
+		{Type: github.String("IssuesEvent"), RawPayload: &triage},
+		{Type: github.String("IssuesEvent"), RawPayload: &bug},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadAction: "labeled", PayloadLabelName: "triage"},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{PayloadLabelName: "triage", Negate: true},
+			Want:      events[2],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_maxAge(t *testing.T) {
+	now := time.Date(2017, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	events := []*github.Event{
+		{CreatedAt: nil},
+		{CreatedAt: timePtr(now.Add(-time.Hour))},
+		{CreatedAt: timePtr(now.Add(-time.Hour - time.Nanosecond))},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      []*github.Event
+	}{
+		{
+			Condition: Condition{MaxAge: time.Hour},
+			Want:      []*github.Event{events[1]},
+		},
+		{
+			Condition: Condition{MaxAge: time.Hour, Negate: true},
+			Want:      []*github.Event{events[2]},
+		},
+		{
+			Condition: Condition{MaxAge: time.Hour + time.Nanosecond},
+			Want:      []*github.Event{events[1], events[2]},
+		},
+	}
+
+	for _, test := range tests {
+		var have []*github.Event
+		for _, event := range events {
+			if test.Condition.MatchesWithClock(event, now) {
+				have = append(have, event)
+			}
+		}
+		if !reflect.DeepEqual(have, test.Want) {
+			t.Errorf("condition %+v matched %v events, want %v", test.Condition, len(have), len(test.Want))
+		}
+	}
+
+	filter := Filter{Conditions: []Condition{{MaxAge: time.Hour}}}
+	if !filter.MatchesWithClock(events[1], now) {
+		t.Errorf("expected filter to match event created MaxAge ago")
+	}
+}
+
+// timePtr returns a pointer to t.
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestCondition_payloadDeploymentStatusState(t *testing.T) {
+	var (
+		failure = json.RawMessage(`{"action":"created","deployment_status":{"state":"failure"},"deployment":{"environment":"production"}}`)
+		success = json.RawMessage(`{"action":"created","deployment_status":{"state":"success"},"deployment":{"environment":"production"}}`)
+	)
+
+	events := []*github.Event{
+		{Type: github.String("DeploymentStatusEvent"), RawPayload: nil},
+		{Type: github.String("DeploymentStatusEvent"), RawPayload: &failure},
+		{Type: github.String("DeploymentStatusEvent"), RawPayload: &success},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadDeploymentStatusState: "FAILURE"},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{PayloadDeploymentStatusState: "failure", Negate: true},
+			Want:      events[2],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadCommentCommand(t *testing.T) {
+	var (
+		start  = json.RawMessage(`{"action":"created","comment":{"body":"\n  /Deploy production\nthanks"}}`)
+		only   = json.RawMessage(`{"action":"created","comment":{"body":"/deploy"}}`)
+		middle = json.RawMessage(`{"action":"created","comment":{"body":"please /deploy"}}`)
+		prefix = json.RawMessage(`{"action":"created","comment":{"body":"/deployment status?"}}`)
+	)
+
+	events := []*github.Event{
+		{RawPayload: nil},
+		{RawPayload: &start},
+		{RawPayload: &only},
+		{RawPayload: &middle},
+		{RawPayload: &prefix},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      []*github.Event
+	}{
+		{
+			Condition: Condition{PayloadCommentCommand: "/deploy"},
+			Want:      []*github.Event{events[1], events[2]},
+		},
+		{
+			Condition: Condition{PayloadCommentCommand: "/deploy", Negate: true},
+			Want:      []*github.Event{events[3], events[4]},
+		},
+	}
+
+	for _, test := range tests {
+		var have []*github.Event
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				have = append(have, event)
+			}
+		}
+		if !reflect.DeepEqual(have, test.Want) {
+			t.Errorf("condition %+v matched %v events, want %v", test.Condition, len(have), len(test.Want))
+		}
+	}
+}
+
+func TestCondition_payloadIssueMilestoneDue(t *testing.T) {
+	var (
+		due       = json.RawMessage(`{"action":"opened","issue":{"milestone":{"title":"v1","due_on":"2017-01-01T08:00:00Z"}}}`)
+		noDueDate = json.RawMessage(`{"action":"opened","issue":{"milestone":{"title":"v1","due_on":null}}}`)
+	)
+
+	events := []*github.Event{
+		{RawPayload: nil},
+		{RawPayload: &due},
+		{RawPayload: &noDueDate},
+	}
+
+	dueOn := time.Date(2017, 1, 1, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadIssueMilestoneDueBefore: dueOn.Add(time.Second)},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{PayloadIssueMilestoneDueBefore: dueOn},
+			Want:      nil,
+		},
+		{
+			Condition: Condition{PayloadIssueMilestoneDueAfter: dueOn.Add(-time.Second)},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{PayloadIssueMilestoneDueAfter: dueOn},
+			Want:      nil,
+		},
+		{
+			// Null due date continues to not match when negated
+			Condition: Condition{PayloadIssueMilestoneDueBefore: dueOn, Negate: true},
+			Want:      events[1],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_actorAllowed(t *testing.T) {
+	events := []*github.Event{
+		{Actor: nil},
+		{Actor: &github.User{Login: github.String("octocat")}},
+		{Actor: &github.User{Login: github.String("hubot")}},
+	}
+
+	allowed := func(login string) bool {
+		return login == "octocat"
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{ActorAllowed: allowed},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{ActorAllowed: allowed, Negate: true},
+			Want:      events[2],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadPullRequestCommits(t *testing.T) {
+	var (
+		single = json.RawMessage(`{"action":"opened","pull_request":{"commits":1}}`)
+		many   = json.RawMessage(`{"action":"opened","pull_request":{"commits":12}}`)
+	)
+
+	events := []*github.Event{
+		{RawPayload: nil},
+		{RawPayload: &single},
+		{RawPayload: &many},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadPullRequestCommitsMax: 1},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{PayloadPullRequestCommitsMin: 2},
+			Want:      events[2],
+		},
+		{
+			Condition: Condition{PayloadPullRequestCommitsMin: 12, PayloadPullRequestCommitsMax: 12},
+			Want:      events[2],
+		},
+		{
+			Condition: Condition{PayloadPullRequestCommitsMin: 13},
+			Want:      nil,
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadPullRequestMerged(t *testing.T) {
+	var (
+		opened   = json.RawMessage(`{"action":"opened","pull_request":{"merged":false}}`)
+		closed   = json.RawMessage(`{"action":"closed","pull_request":{"merged":false}}`)
+		merged   = json.RawMessage(`{"action":"closed","pull_request":{"merged":true}}`)
+		noPR     = json.RawMessage(`{"action":"closed"}`)
+		noMerged = json.RawMessage(`{"action":"closed","pull_request":{}}`)
+	)
+
+	events := []*github.Event{
+		{RawPayload: nil},
+		{RawPayload: &opened},
+		{RawPayload: &closed},
+		{RawPayload: &merged},
+		{RawPayload: &noPR},
+		{RawPayload: &noMerged},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      []*github.Event
+	}{
+		{
+			Condition: Condition{PayloadPullRequestAction: "opened"},
+			Want:      []*github.Event{events[1]},
+		},
+		{
+			Condition: Condition{PayloadPullRequestAction: "CLOSED"},
+			Want:      []*github.Event{events[2], events[3], events[5]},
+		},
+		{
+			Condition: Condition{PayloadPullRequestAction: "closed", Negate: true},
+			Want:      []*github.Event{events[1]},
+		},
+		{
+			Condition: Condition{PayloadPullRequestMerged: github.Bool(true)},
+			Want:      []*github.Event{events[3]},
+		},
+		{
+			Condition: Condition{PayloadPullRequestMerged: github.Bool(false)},
+			Want:      []*github.Event{events[1], events[2]},
+		},
+		{
+			Condition: Condition{PayloadPullRequestAction: "closed", PayloadPullRequestMerged: github.Bool(false)},
+			Want:      []*github.Event{events[2]},
+		},
+		{
+			Condition: Condition{PayloadPullRequestAction: "closed", PayloadPullRequestMerged: github.Bool(true), Negate: true},
+			Want:      []*github.Event{events[1], events[2]},
+		},
+	}
+
+	for _, test := range tests {
+		var have []*github.Event
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				have = append(have, event)
+			}
+		}
+		if !reflect.DeepEqual(have, test.Want) {
+			t.Errorf("condition %+v matched %v events, want %v", test.Condition, len(have), len(test.Want))
+		}
+	}
+}
+
+func TestCondition_payloadPullRequestBaseRef(t *testing.T) {
+	var (
+		main    = json.RawMessage(`{"action":"opened","pull_request":{"base":{"ref":"main"}}}`)
+		feature = json.RawMessage(`{"action":"opened","pull_request":{"base":{"ref":"feature"}}}`)
+		issue   = json.RawMessage(`{"action":"opened","issue":{"number":1}}`)
+	)
+
+	events := []*github.Event{
+		{RawPayload: nil},
+		{RawPayload: &main},
+		{RawPayload: &feature},
+		{RawPayload: &issue},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadPullRequestBaseRef: "main"},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{PayloadPullRequestBaseRef: "MAIN"},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{PayloadPullRequestBaseRef: "MAIN", CaseSensitive: true},
+			Want:      nil,
+		},
+		{
+			Condition: Condition{PayloadPullRequestBaseRef: "main", Negate: true},
+			Want:      events[2],
+		},
+		{
+			Condition: Condition{PayloadPullRequestBaseRef: "develop"},
+			Want:      nil,
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadIssueBodyHasTaskList(t *testing.T) {
+	var (
+		unchecked = json.RawMessage(`{"issue":{"body":"Tasks:\n- [ ] first\n- [ ] second"}}`)
+		checked   = json.RawMessage(`{"issue":{"body":"Done:\r\n  - [x] first"}}`)
+		none      = json.RawMessage(`{"issue":{"body":"No tasks, just a - [ ] in text and\n- a list"}}`)
+	)
+
+	events := []*github.Event{
+		{RawPayload: nil},
+		{RawPayload: &unchecked},
+		{RawPayload: &checked},
+		{RawPayload: &none},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      []*github.Event
+	}{
+		{
+			Condition: Condition{PayloadIssueBodyHasTaskList: true},
+			Want:      []*github.Event{events[1], events[2]},
+		},
+		{
+			Condition: Condition{PayloadIssueBodyHasTaskList: true, Negate: true},
+			Want:      []*github.Event{events[3]},
+		},
+	}
+
+	for _, test := range tests {
+		var have []*github.Event
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				have = append(have, event)
+			}
+		}
+		if !reflect.DeepEqual(have, test.Want) {
+			t.Errorf("condition %+v matched %v events, want %v", test.Condition, len(have), len(test.Want))
+		}
+	}
+}
+
+func TestCondition_payloadPushFilesChanged(t *testing.T) {
+	var (
+		// 4 distinct files, main.go and README.md are changed by both commits
+		overlap = json.RawMessage(`{"ref":"refs/heads/master","commits":[
+			{"added":["main.go","main_test.go"],"modified":["README.md"],"removed":[]},
+			{"added":[],"modified":["main.go","README.md"],"removed":["old.go"]}
+		]}`)
+		empty = json.RawMessage(`{"ref":"refs/heads/master","commits":[]}`)
+	)
+
+	events := []*github.Event{
+		{RawPayload: nil},
+		{RawPayload: &overlap},
+		{RawPayload: &empty},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadPushFilesChangedMin: 4, PayloadPushFilesChangedMax: 4},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{PayloadPushFilesChangedMin: 5},
+			Want:      nil,
+		},
+		{
+			Condition: Condition{PayloadPushFilesChangedMin: 1, Negate: true},
+			Want:      events[2],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadLabelNamePullRequest(t *testing.T) {
+	var (
+		issueLabeled = json.RawMessage(`{"action":"labeled","label":{"name":"needs-review"},"issue":{"number":1}}`)
+		prLabeled    = json.RawMessage(`{"action":"labeled","label":{"name":"needs-review"},"pull_request":{"number":2}}`)
+		prUnlabeled  = json.RawMessage(`{"action":"unlabeled","label":{"name":"needs-review"},"pull_request":{"number":2}}`)
+	)
+
+	events := []*github.Event{
+		{Type: github.String("IssuesEvent"), RawPayload: &issueLabeled},
+		{Type: github.String("PullRequestEvent"), RawPayload: &prLabeled},
+		{Type: github.String("PullRequestEvent"), RawPayload: &prUnlabeled},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{Type: "IssuesEvent", PayloadAction: "labeled", PayloadLabelName: "needs-review"},
+			Want:      events[0],
+		},
+		{
+			Condition: Condition{Type: "PullRequestEvent", PayloadAction: "labeled", PayloadLabelName: "needs-review"},
+			Want:      events[1],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadRepositoryStarsMin(t *testing.T) {
+	var (
+		popular = json.RawMessage(`{"repository":{"full_name":"owner/popular","stargazers_count":1000}}`)
+		small   = json.RawMessage(`{"repository":{"full_name":"owner/small","stargazers_count":999}}`)
+		absent  = json.RawMessage(`{"repository":{"full_name":"owner/unknown"}}`)
+	)
+
+	events := []*github.Event{
+		{RawPayload: nil},
+		{RawPayload: &popular},
+		{RawPayload: &small},
+		{RawPayload: &absent},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadRepositoryStarsMin: 1000},
+			Want:      events[1],
+		},
+		{
+			// Absent field continues to not match when negated
+			Condition: Condition{PayloadRepositoryStarsMin: 1000, Negate: true},
+			Want:      events[2],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_issueLocked(t *testing.T) {
+	var (
+		locked   = json.RawMessage(`{"action":"locked","issue":{"number":1,"locked":true}}`)
+		unlocked = json.RawMessage(`{"action":"unlocked","issue":{"number":1,"locked":false}}`)
+	)
+
+	events := []*github.Event{
+		{RawPayload: nil},
+		{RawPayload: &locked},
+		{RawPayload: &unlocked},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{CompareIssueLocked: true, IssueLocked: true},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{CompareIssueLocked: true, IssueLocked: false},
+			Want:      events[2],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadCommentWasEdited(t *testing.T) {
+	var (
+		created = json.RawMessage(`{"action":"created","comment":{"body":"new"}}`)
+		edited  = json.RawMessage(`{"action":"edited","changes":{"body":{"from":"old"}},"comment":{"body":"new"}}`)
+	)
+
+	events := []*github.Event{
+		{Type: github.String("IssueCommentEvent"), RawPayload: nil},
+		{Type: github.String("IssueCommentEvent"), RawPayload: &created},
+		{Type: github.String("IssueCommentEvent"), RawPayload: &edited},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadCommentWasEdited: true},
+			Want:      events[2],
+		},
+		{
+			Condition: Condition{PayloadCommentWasEdited: true, Negate: true},
+			Want:      events[1],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadIssueTransferred(t *testing.T) {
+	var (
+		opened      = json.RawMessage(`{"action":"opened","issue":{"number":1}}`)
+		transferred = json.RawMessage(`{
+			"action": "transferred",
+			"changes": {
+				"new_issue": {"number": 7, "title": "Crash on start"},
+				"new_repository": {"id": 2, "full_name": "owner/other"}
+			},
+			"issue": {"number": 1, "title": "Crash on start"},
+			"repository": {"id": 1, "full_name": "owner/repo"}
+		}`)
+		noChanges = json.RawMessage(`{"action":"transferred","issue":{"number":1}}`)
+	)
+
+	events := []*github.Event{
+		{Type: github.String("IssuesEvent"), RawPayload: nil},
+		{Type: github.String("IssuesEvent"), RawPayload: &opened},
+		{Type: github.String("IssuesEvent"), RawPayload: &transferred},
+		{Type: github.String("IssuesEvent"), RawPayload: &noChanges},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      []*github.Event
+	}{
+		{
+			Condition: Condition{PayloadIssueTransferred: true},
+			Want:      []*github.Event{events[2]},
+		},
+		{
+			Condition: Condition{PayloadIssueTransferred: true, Negate: true},
+			Want:      []*github.Event{events[1], events[3]},
+		},
+	}
+
+	for _, test := range tests {
+		var have []*github.Event
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				have = append(have, event)
+			}
+		}
+		if !reflect.DeepEqual(have, test.Want) {
+			t.Errorf("condition %+v matched %v events, want %v", test.Condition, len(have), len(test.Want))
+		}
+	}
+}
+
+func TestCondition_actorLogin(t *testing.T) {
+	events := []*github.Event{
+		{Actor: nil},
+		{Actor: &github.User{Login: github.String("bradleyfalzon")}},
+		{Actor: &github.User{Login: github.String("octocat")}},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{ActorLogin: "BradleyFalzon"},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{ActorLogin: "octocat"},
+			Want:      events[2],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_actorID(t *testing.T) {
+	events := []*github.Event{
+		{Actor: nil},
+		{Actor: &github.User{ID: github.Int(1)}},
+		{Actor: &github.User{ID: github.Int(2)}},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{ActorID: 1},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{ActorID: 2},
+			Want:      events[2],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadPushIsTag(t *testing.T) {
+	var (
+		tag    = json.RawMessage(`{"ref":"refs/tags/v1.0.0","before":"0000000","head":"abc1234"}`)
+		branch = json.RawMessage(`{"ref":"refs/heads/master","before":"0000000","head":"abc1234"}`)
+	)
+
+	events := []*github.Event{
+		{Type: github.String("PushEvent"), RawPayload: nil},
+		{Type: github.String("PushEvent"), RawPayload: &tag},
+		{Type: github.String("PushEvent"), RawPayload: &branch},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadPushIsTag: true},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{PayloadPushIsTag: true, Negate: true},
+			Want:      events[2],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_repositoryName(t *testing.T) {
+	events := []*github.Event{
+		{Repo: nil},
+		{Repo: &github.Repository{Name: github.String("bradleyfalzon/ghfilter")}},
+		{Repo: &github.Repository{Name: github.String("google/go-github")}},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{RepositoryName: "BradleyFalzon/GHFilter"},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{RepositoryName: "google/go-github"},
+			Want:      events[2],
+		},
+		{
+			// Events without a repository never match
+			Condition: Condition{RepositoryName: "bradleyfalzon/ghfilter", Negate: true},
+			Want:      events[2],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadWorkflowJobConclusion(t *testing.T) {
+	var (
+		failure = json.RawMessage(`{"action":"completed","workflow_job":{"name":"test","status":"completed","conclusion":"failure"}}`)
+		success = json.RawMessage(`{"action":"completed","workflow_job":{"name":"test","status":"completed","conclusion":"success"}}`)
+	)
+
+	events := []*github.Event{
+		{Type: github.String("WorkflowJobEvent"), RawPayload: nil},
+		{Type: github.String("WorkflowJobEvent"), RawPayload: &failure},
+		{Type: github.String("WorkflowJobEvent"), RawPayload: &success},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadWorkflowJobConclusion: "FAILURE"},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{PayloadWorkflowJobConclusion: "failure", Negate: true},
+			Want:      events[2],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_repositoryNameGlob(t *testing.T) {
+	events := []*github.Event{
+		{Repo: nil},
+		{Repo: &github.Repository{Name: github.String("bradleyfalzon/ghfilter")}},
+		{Repo: &github.Repository{Name: github.String("google/go-github")}},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{RepositoryNameGlob: "BradleyFalzon/*"},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{RepositoryNameGlob: "*/go-*"},
+			Want:      events[2],
+		},
+		{
+			// Malformed patterns never match
+			Condition: Condition{RepositoryNameGlob: "google/["},
+			Want:      nil,
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+
+	malformed := Condition{RepositoryNameGlob: "google/[", Negate: true}
+	for _, event := range events {
+		if malformed.Matches(event) {
+			t.Errorf("expected negated malformed pattern to not match event: %+v", event)
+		}
+	}
+	if (&Condition{RepositoryNameGlob: "*/*", Negate: true}).Matches(events[0]) {
+		t.Errorf("expected negated pattern to not match event without a repository")
+	}
+	if err := malformed.checkRegexps(); err == nil {
+		t.Errorf("expected error checking malformed pattern")
+	}
+}
+
+func TestCondition_payloadWorkflowJobLabelsAny(t *testing.T) {
+	var (
+		selfHosted = json.RawMessage(`{"action":"queued","workflow_job":{"name":"test","labels":["self-hosted","linux"]}}`)
+		hosted     = json.RawMessage(`{"action":"queued","workflow_job":{"name":"test","labels":["ubuntu-latest"]}}`)
+	)
+
+	events := []*github.Event{
+		{Type: github.String("WorkflowJobEvent"), RawPayload: nil},
+		{Type: github.String("WorkflowJobEvent"), RawPayload: &selfHosted},
+		{Type: github.String("WorkflowJobEvent"), RawPayload: &hosted},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadWorkflowJobLabelsAny: []string{"Self-Hosted", "windows"}},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{PayloadWorkflowJobLabelsAny: []string{"self-hosted"}, Negate: true},
+			Want:      events[2],
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadActionTerminalOpening(t *testing.T) {
+	var (
+		issueClosed    = json.RawMessage(`{"action":"closed","issue":{"number":1}}`)
+		releaseDeleted = json.RawMessage(`{"action":"deleted","release":{"tag_name":"v1.0.0"}}`)
+		prOpened       = json.RawMessage(`{"action":"opened","number":1,"pull_request":{"number":1}}`)
+		releasePublish = json.RawMessage(`{"action":"published","release":{"tag_name":"v1.0.0"}}`)
+		issueLabeled   = json.RawMessage(`{"action":"labeled","issue":{"number":1}}`)
+	)
+
+	events := []*github.Event{
+		{Type: github.String("IssuesEvent"), RawPayload: &issueClosed},
+		{Type: github.String("ReleaseEvent"), RawPayload: &releaseDeleted},
+		{Type: github.String("PullRequestEvent"), RawPayload: &prOpened},
+		{Type: github.String("ReleaseEvent"), RawPayload: &releasePublish},
+		{Type: github.String("IssuesEvent"), RawPayload: &issueLabeled},
+		{Type: github.String("IssuesEvent"), RawPayload: nil},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      []*github.Event
+	}{
+		{
+			Condition: Condition{PayloadActionTerminal: true},
+			Want:      events[:2],
+		},
+		{
+			Condition: Condition{PayloadActionOpening: true},
+			Want:      events[2:4],
+		},
+		{
+			Condition: Condition{PayloadActionTerminal: true, Negate: true},
+			Want:      events[2:5],
+		},
+	}
+
+	for _, test := range tests {
+		var have []*github.Event
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				have = append(have, event)
+			}
+		}
+		if !reflect.DeepEqual(have, test.Want) {
+			t.Errorf("condition %+v matched %v events, want %v", test.Condition, len(have), len(test.Want))
+		}
+	}
+}
+
+func TestCondition_test(t *testing.T) {
+	var (
+		valid   = json.RawMessage(`{"action":"opened","issue":{"title":"Title"}}`)
+		invalid = json.RawMessage(`{"action":`)
+	)
+
+	tests := []struct {
+		condition Condition
+		payload   *json.RawMessage
+		want      bool
+		wantErr   bool
+	}{
+		{Condition{PayloadAction: "opened"}, &valid, true, false},
+		{Condition{PayloadAction: "closed"}, &valid, false, false},
+		{Condition{PayloadAction: "opened"}, nil, false, false},
+		{Condition{PayloadAction: "opened"}, &invalid, false, true},
+		{Condition{PayloadAction: "opened", Negate: true}, &invalid, false, true},
+		{Condition{PayloadIssueTitleRegexp: "("}, &valid, false, true},
+	}
+
+	for _, test := range tests {
+		event := &github.Event{RawPayload: test.payload}
+		have, err := test.condition.Test(event)
+		if (err != nil) != test.wantErr {
+			t.Errorf("condition %v unexpected error: %v", test.condition, err)
+		}
+		if have != test.want {
+			t.Errorf("condition %v have: %v, want: %v", test.condition, have, test.want)
+		}
+		if matches := test.condition.Matches(event); matches != have {
+			t.Errorf("condition %v Matches: %v, Test: %v", test.condition, matches, have)
+		}
+	}
+}
+
+func TestCondition_payloadCommentLength(t *testing.T) {
+	var (
+		empty     = json.RawMessage(`{"action":"created","comment":{"body":""}}`)
+		short     = json.RawMessage(`{"action":"created","comment":{"body":"👍"}}`)
+		max       = json.RawMessage(`{"action":"created","comment":{"body":"` + strings.Repeat("é", 5) + `"}}`)
+		overLimit = json.RawMessage(`{"action":"created","comment":{"body":"` + strings.Repeat("é", 6) + `"}}`)
+	)
+
+	events := []*github.Event{
+		{Type: github.String("IssueCommentEvent"), RawPayload: nil},
+		{Type: github.String("IssueCommentEvent"), RawPayload: &empty},
+		{Type: github.String("IssueCommentEvent"), RawPayload: &short},
+		{Type: github.String("IssueCommentEvent"), RawPayload: &max},
+		{Type: github.String("IssueCommentEvent"), RawPayload: &overLimit},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      []*github.Event
+	}{
+		{
+			// Multibyte characters are counted once
+			Condition: Condition{PayloadCommentLengthMin: 1, PayloadCommentLengthMax: 5},
+			Want:      events[2:4],
+		},
+		{
+			Condition: Condition{PayloadCommentLengthMin: 5},
+			Want:      events[3:],
+		},
+		{
+			Condition: Condition{PayloadCommentLengthMax: 1},
+			Want:      events[1:3],
+		},
+		{
+			Condition: Condition{PayloadCommentLengthMin: 1, PayloadCommentLengthMax: 5, Negate: true},
+			Want:      []*github.Event{events[1], events[4]},
+		},
+	}
+
+	for _, test := range tests {
+		var have []*github.Event
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				have = append(have, event)
+			}
+		}
+		if !reflect.DeepEqual(have, test.Want) {
+			t.Errorf("condition %+v matched %v events, want %v", test.Condition, len(have), len(test.Want))
+		}
+	}
+}
+
+func TestCondition_payloadURLHostRegexp(t *testing.T) {
+	var (
+		cloud      = json.RawMessage(`{"action":"opened","repository":{"full_name":"owner/repo","html_url":"https://github.com/owner/repo"}}`)
+		enterprise = json.RawMessage(`{"action":"opened","repository":{"full_name":"owner/repo","html_url":"https://github.example.com/owner/repo"}}`)
+		noURL      = json.RawMessage(`{"action":"opened","repository":{"full_name":"owner/repo"}}`)
+	)
+
+	events := []*github.Event{
+		{Type: github.String("IssuesEvent"), RawPayload: nil},
+		{Type: github.String("IssuesEvent"), RawPayload: &cloud},
+		{Type: github.String("IssuesEvent"), RawPayload: &enterprise},
+		{Type: github.String("IssuesEvent"), RawPayload: &noURL},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadURLHostRegexp: `\.example\.com$`},
+			Want:      events[2],
+		},
+		{
+			Condition: Condition{PayloadURLHostRegexp: `\.example\.com$`, Negate: true},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{PayloadURLHostRegexp: `(`},
+			Want:      nil,
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadPullRequestHeadRefRegexp(t *testing.T) {
+	var (
+		release = json.RawMessage(`{"action":"opened","pull_request":{"head":{"ref":"release/1.2"}}}`)
+		feature = json.RawMessage(`{"action":"opened","pull_request":{"head":{"ref":"feature/release"}}}`)
+		issue   = json.RawMessage(`{"action":"opened","issue":{"number":1}}`)
+	)
+
+	events := []*github.Event{
+		{Type: github.String("PullRequestEvent"), RawPayload: nil},
+		{Type: github.String("PullRequestEvent"), RawPayload: &release},
+		{Type: github.String("PullRequestEvent"), RawPayload: &feature},
+		{Type: github.String("IssuesEvent"), RawPayload: &issue},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadPullRequestHeadRefRegexp: `^release/`},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{PayloadPullRequestHeadRefRegexp: `^release/`, Negate: true},
+			Want:      events[2],
+		},
+		{
+			Condition: Condition{PayloadPullRequestHeadRefRegexp: `^hotfix/`},
+			Want:      nil,
+		},
+		{
+			Condition: Condition{PayloadPullRequestHeadRefRegexp: `(`},
+			Want:      nil,
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadRef(t *testing.T) {
+	var (
+		main    = json.RawMessage(`{"ref":"refs/heads/main","commits":[]}`)
+		feature = json.RawMessage(`{"ref":"refs/heads/feature/main","commits":[]}`)
+		noRef   = json.RawMessage(`{"commits":[]}`)
+	)
+
+	events := []*github.Event{
+		{Type: github.String("PushEvent"), RawPayload: nil},
+		{Type: github.String("PushEvent"), RawPayload: &main},
+		{Type: github.String("PushEvent"), RawPayload: &feature},
+		{Type: github.String("PushEvent"), RawPayload: &noRef},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadRef: "refs/heads/main"},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{PayloadRef: "refs/heads/main", Negate: true},
+			Want:      events[2],
+		},
+		{
+			Condition: Condition{PayloadRef: "refs/heads/MAIN"},
+			Want:      nil,
+		},
+		{
+			Condition: Condition{PayloadRefRegexp: `^refs/heads/feature/`},
+			Want:      events[2],
+		},
+		{
+			Condition: Condition{PayloadRefRegexp: `^refs/heads/feature/`, Negate: true},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{PayloadRefRegexp: `(`},
+			Want:      nil,
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadCommitsCount(t *testing.T) {
+	var (
+		none     = json.RawMessage(`{"ref":"refs/heads/main","commits":[]}`)
+		single   = json.RawMessage(`{"ref":"refs/heads/main","commits":[{}]}`)
+		many     = json.RawMessage(`{"ref":"refs/heads/main","commits":[{},{},{},{},{}]}`)
+		noCommit = json.RawMessage(`{"ref":"refs/heads/main"}`)
+	)
+
+	events := []*github.Event{
+		{Type: github.String("PushEvent"), RawPayload: nil},
+		{Type: github.String("PushEvent"), RawPayload: &none},
+		{Type: github.String("PushEvent"), RawPayload: &single},
+		{Type: github.String("PushEvent"), RawPayload: &many},
+		{Type: github.String("PushEvent"), RawPayload: &noCommit},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      []*github.Event
+	}{
+		{
+			Condition: Condition{PayloadCommitsCountMin: 2},
+			Want:      []*github.Event{events[3]},
+		},
+		{
+			Condition: Condition{PayloadCommitsCountMax: 1},
+			Want:      []*github.Event{events[1], events[2]},
+		},
+		{
+			Condition: Condition{PayloadCommitsCountMin: 1, PayloadCommitsCountMax: 5},
+			Want:      []*github.Event{events[2], events[3]},
+		},
+		{
+			Condition: Condition{PayloadCommitsCountMin: 2, Negate: true},
+			Want:      []*github.Event{events[1], events[2]},
+		},
+		{
+			Condition: Condition{PayloadCommitsCountMin: 6},
+			Want:      nil,
+		},
+	}
+
+	for _, test := range tests {
+		var have []*github.Event
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				have = append(have, event)
+			}
+		}
+		if !reflect.DeepEqual(have, test.Want) {
+			t.Errorf("condition %+v matched %v events, want %v", test.Condition, len(have), len(test.Want))
+		}
+	}
+}
+
+func TestCondition_payloadCommitMessageRegexp(t *testing.T) {
+	var (
+		hotfix = json.RawMessage(`{"ref":"refs/heads/main","commits":[
+			{"message":"Update README"},
+			{"message":"HOTFIX: handle nil pointer\n\nFixes #1"},
+			{"message":"Bump version"}
+		]}`)
+		regular = json.RawMessage(`{"ref":"refs/heads/main","commits":[
+			{"message":"Update README"},
+			{"message":"Bump version"}
+		]}`)
+		noCommits = json.RawMessage(`{"ref":"refs/heads/main"}`)
+	)
+
+	events := []*github.Event{
+		{Type: github.String("PushEvent"), RawPayload: nil},
+		{Type: github.String("PushEvent"), RawPayload: &hotfix},
+		{Type: github.String("PushEvent"), RawPayload: &regular},
+		{Type: github.String("PushEvent"), RawPayload: &noCommits},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadCommitMessageRegexp: `(?i)hotfix`},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{PayloadCommitMessageRegexp: `(?i)hotfix`, Negate: true},
+			Want:      events[2],
+		},
+		{
+			Condition: Condition{PayloadCommitMessageRegexp: `(?m)^Fixes #\d+$`},
+			Want:      events[1],
+		},
+		{
+			Condition: Condition{PayloadCommitMessageRegexp: `(`},
+			Want:      nil,
+		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadCommentBodyRegexp(t *testing.T) {
+	var (
+		deploy    = json.RawMessage(`{"action":"created","comment":{"body":"/deploy staging"}}`)
+		review    = json.RawMessage(`{"action":"created","comment":{"body":"/deploy production","path":"main.go"}}`)
+		regular   = json.RawMessage(`{"action":"created","comment":{"body":"Please /deploy when ready"}}`)
+		noComment = json.RawMessage(`{"action":"opened","issue":{"body":"/deploy staging"}}`)
+	)
+
+	events := []*github.Event{
+		{Type: github.String("IssueCommentEvent"), RawPayload: nil},
+		{Type: github.String("IssueCommentEvent"), RawPayload: &deploy},
+		{Type: github.String("PullRequestReviewCommentEvent"), RawPayload: &review},
+		{Type: github.String("IssueCommentEvent"), RawPayload: &regular},
+		{Type: github.String("IssuesEvent"), RawPayload: &noComment},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      []*github.Event
+	}{
+		{
+			Condition: Condition{PayloadCommentBodyRegexp: `^/deploy\b`},
+			Want:      []*github.Event{events[1], events[2]},
+		},
+		{
+			Condition: Condition{PayloadCommentBodyRegexp: `^/deploy\b`, Negate: true},
+			Want:      []*github.Event{events[3]},
+		},
+		{
+			Condition: Condition{PayloadCommentBodyRegexp: `^/deploy production$`},
+			Want:      []*github.Event{events[2]},
+		},
+		{
+			Condition: Condition{PayloadCommentBodyRegexp: `(`},
+			Want:      nil,
+		},
+	}
+
+	for _, test := range tests {
+		var have []*github.Event
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				have = append(have, event)
+			}
+		}
+		if !reflect.DeepEqual(have, test.Want) {
+			t.Errorf("condition %+v matched %v events, want %v", test.Condition, len(have), len(test.Want))
+		}
+	}
+}
+
+func TestCondition_payloadSenderIsRepoOwner(t *testing.T) {
+	var (
+		owner = json.RawMessage(`{
+			"action": "opened",
+			"issue": {"number": 1},
+			"repository": {"full_name": "octocat/hello-world", "owner": {"login": "octocat", "type": "User"}},
+			"sender": {"login": "OctoCat", "type": "User"}
+		}`)
+		contributor = json.RawMessage(`{
+			"action": "opened",
+			"issue": {"number": 2},
+			"repository": {"full_name": "octocat/hello-world", "owner": {"login": "octocat", "type": "User"}},
+			"sender": {"login": "hubot", "type": "User"}
+		}`)
+		noOwner = json.RawMessage(`{"action":"opened","sender":{"login":"octocat"}}`)
+	)
+
+	events := []*github.Event{
+		{Type: github.String("IssuesEvent"), RawPayload: nil},
+		{Type: github.String("IssuesEvent"), RawPayload: &owner},
+		{Type: github.String("IssuesEvent"), RawPayload: &contributor},
+		{Type: github.String("IssuesEvent"), RawPayload: &noOwner},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      *github.Event
+	}{
+		{
+			Condition: Condition{PayloadSenderIsRepoOwner: true},
+			Want:      events[1],
 		},
 		{
-			Condition: Condition{ComparePublic: true, Public: false, Negate: true},
-			Want:      `If event is not not public`, // :/
+			Condition: Condition{PayloadSenderIsRepoOwner: true, Negate: true},
+			Want:      events[2],
 		},
+	}
+
+	for _, test := range tests {
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				if !reflect.DeepEqual(event, test.Want) {
+					// Incorrectly matched
+					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
+				}
+			} else if reflect.DeepEqual(event, test.Want) {
+				// Incorrectly missed
+				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+			}
+		}
+	}
+}
+
+func TestCondition_payloadPackageEcosystem(t *testing.T) {
+	var (
+		npm = json.RawMessage(`{
+			"action": "published",
+			"package": {
+				"id": 1,
+				"name": "hello-world-npm",
+				"ecosystem": "npm",
+				"package_type": "npm",
+				"package_version": {"version": "1.0.0"}
+			},
+			"repository": {"full_name": "octocat/hello-world"}
+		}`)
+		container = json.RawMessage(`{"action":"published","package":{"name":"hello-world","package_type":"CONTAINER"}}`)
+		legacy    = json.RawMessage(`{"action":"published","package":{"name":"hello-world","ecosystem":"npm"}}`)
+		noPackage = json.RawMessage(`{"action":"published"}`)
+	)
+
+	events := []*github.Event{
+		{Type: github.String("PackageEvent"), RawPayload: nil},
+		{Type: github.String("PackageEvent"), RawPayload: &npm},
+		{Type: github.String("PackageEvent"), RawPayload: &container},
+		{Type: github.String("PackageEvent"), RawPayload: &legacy},
+		{Type: github.String("PackageEvent"), RawPayload: &noPackage},
+	}
+
+	tests := []struct {
+		Condition Condition
+		Want      []*github.Event
+	}{
 		{
-			Condition: Condition{OrganizationID: 1},
-			Want:      `If organization ID is 1`,
+			Condition: Condition{PayloadPackageEcosystem: "npm"},
+			Want:      []*github.Event{events[1], events[3]},
 		},
 		{
-			Condition: Condition{OrganizationID: 1, Negate: true},
-			Want:      `If organization ID is not 1`,
+			Condition: Condition{PayloadPackageEcosystem: "container"},
+			Want:      []*github.Event{events[2]},
 		},
 		{
-			Condition: Condition{RepositoryID: 1},
-			Want:      `If repository ID is 1`,
+			Condition: Condition{PayloadPackageEcosystem: "npm", Negate: true},
+			Want:      []*github.Event{events[2]},
 		},
 		{
-			Condition: Condition{RepositoryID: 1, Negate: true},
-			Want:      `If repository ID is not 1`,
+			Condition: Condition{PayloadPackageEcosystem: "maven"},
+			Want:      nil,
 		},
 	}
 
 	for _, test := range tests {
-		if have := test.Condition.String(); have != test.Want {
-			t.Errorf("String does not match\nhave: %v\nwant: %v", have, test.Want)
+		var have []*github.Event
+		for _, event := range events {
+			if test.Condition.Matches(event) {
+				have = append(have, event)
+			}
+		}
+		if !reflect.DeepEqual(have, test.Want) {
+			t.Errorf("condition %+v matched %v events, want %v", test.Condition, len(have), len(test.Want))
 		}
 	}
-
 }
 
-func TestCondition_type(t *testing.T) {
+func TestCondition_payloadIssueNumber(t *testing.T) {
+	var (
+		issue42 = json.RawMessage(`{"action":"opened","issue":{"number":42}}`)
+		issue7  = json.RawMessage(`{"action":"opened","issue":{"number":7}}`)
+		pr42    = json.RawMessage(`{"action":"opened","number":42,"pull_request":{"number":42}}`)
+		push    = json.RawMessage(`{"ref":"refs/heads/main","number":42}`)
+	)
+
 	events := []*github.Event{
-		{
-			Type: github.String("IssuesEvent"),
-		},
+		{Type: github.String("IssuesEvent"), RawPayload: nil},
+		{Type: github.String("IssuesEvent"), RawPayload: &issue42},
+		{Type: github.String("IssuesEvent"), RawPayload: &issue7},
+		{Type: github.String("PullRequestEvent"), RawPayload: &pr42},
+		{Type: github.String("PushEvent"), RawPayload: &push},
 	}
 
 	tests := []struct {
 		Condition Condition
-		Want      *github.Event
+		Want      []*github.Event
 	}{
 		{
-			Condition: Condition{Type: "NonExistentEvent"},
-			Want:      nil,
+			Condition: Condition{PayloadIssueNumber: 42},
+			Want:      []*github.Event{events[1], events[3]},
 		},
 		{
-			Condition: Condition{Type: "IssuesEvent"},
-			Want:      events[0],
+			Condition: Condition{PayloadIssueNumber: 42, Negate: true},
+			Want:      []*github.Event{events[2]},
 		},
 		{
-			Condition: Condition{Type: "NonExistentEvent", Negate: true},
-			Want:      events[0],
+			Condition: Condition{PayloadIssueNumber: 7, Type: "IssuesEvent"},
+			Want:      []*github.Event{events[2]},
+		},
+		{
+			Condition: Condition{PayloadIssueNumber: 1},
+			Want:      nil,
 		},
 	}
 
 	for _, test := range tests {
+		var have []*github.Event
 		for _, event := range events {
 			if test.Condition.Matches(event) {
-				if !reflect.DeepEqual(event, test.Want) {
-					// Incorrectly matched
-					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
-				}
-			} else if reflect.DeepEqual(event, test.Want) {
-				// Incorrectly missed
-				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+				have = append(have, event)
 			}
 		}
+		if !reflect.DeepEqual(have, test.Want) {
+			t.Errorf("condition %+v matched %v events, want %v", test.Condition, len(have), len(test.Want))
+		}
 	}
 }
 
-func TestCondition_payloadAction(t *testing.T) {
+func TestCondition_payloadIssueState(t *testing.T) {
 	var (
-		opened  = json.RawMessage(`{"action":"opened"}`)
-		created = json.RawMessage(`{"action":"created"}`)
+		open   = json.RawMessage(`{"action":"reopened","issue":{"number":1,"state":"open"}}`)
+		closed = json.RawMessage(`{"action":"closed","issue":{"number":1,"state":"closed"}}`)
+		push   = json.RawMessage(`{"ref":"refs/heads/main"}`)
 	)
 
 	events := []*github.Event{
-		{RawPayload: &opened},
-		{RawPayload: &created},
+		{Type: github.String("IssuesEvent"), RawPayload: nil},
+		{Type: github.String("IssuesEvent"), RawPayload: &open},
+		{Type: github.String("IssuesEvent"), RawPayload: &closed},
+		{Type: github.String("PushEvent"), RawPayload: &push},
 	}
 
 	tests := []struct {
@@ -210,11 +3960,15 @@ func TestCondition_payloadAction(t *testing.T) {
 		Want      *github.Event
 	}{
 		{
-			Condition: Condition{PayloadAction: "opened"},
-			Want:      events[0],
+			Condition: Condition{PayloadIssueState: "open"},
+			Want:      events[1],
 		},
 		{
-			Condition: Condition{PayloadAction: "CREATED"},
+			Condition: Condition{PayloadIssueState: "CLOSED"},
+			Want:      events[2],
+		},
+		{
+			Condition: Condition{PayloadIssueState: "closed", Negate: true},
 			Want:      events[1],
 		},
 	}
@@ -234,95 +3988,135 @@ func TestCondition_payloadAction(t *testing.T) {
 	}
 }
 
-func TestCondition_payloadIssueLabel(t *testing.T) {
+func TestCondition_payloadRepositorySize(t *testing.T) {
 	var (
-		empty    = json.RawMessage(`{"issue":{"labels":[]}}`)
-		contains = json.RawMessage(`{"issue":{"labels":["LBL", "x"]}}`)
+		empty  = json.RawMessage(`{"action":"opened","repository":{"size":0}}`)
+		small  = json.RawMessage(`{"action":"opened","repository":{"size":99999}}`)
+		large  = json.RawMessage(`{"action":"opened","repository":{"size":100000}}`)
+		larger = json.RawMessage(`{"action":"opened","repository":{"size":100001}}`)
+		noSize = json.RawMessage(`{"action":"opened","repository":{"full_name":"owner/repo"}}`)
 	)
 
 	events := []*github.Event{
-		{RawPayload: &empty},
-		{RawPayload: &contains},
+		{Type: github.String("IssuesEvent"), RawPayload: nil},
+		{Type: github.String("IssuesEvent"), RawPayload: &empty},
+		{Type: github.String("IssuesEvent"), RawPayload: &small},
+		{Type: github.String("IssuesEvent"), RawPayload: &large},
+		{Type: github.String("IssuesEvent"), RawPayload: &larger},
+		{Type: github.String("IssuesEvent"), RawPayload: &noSize},
 	}
 
 	tests := []struct {
 		Condition Condition
-		Want      *github.Event
+		Want      []*github.Event
 	}{
 		{
-			Condition: Condition{PayloadIssueLabel: "nomatch"},
-			Want:      nil,
+			Condition: Condition{PayloadRepositorySizeMin: 100000},
+			Want:      []*github.Event{events[3], events[4]},
 		},
 		{
-			Condition: Condition{PayloadIssueLabel: "lbl"},
-			Want:      events[1],
+			Condition: Condition{PayloadRepositorySizeMax: 100000},
+			Want:      []*github.Event{events[1], events[2], events[3]},
+		},
+		{
+			Condition: Condition{PayloadRepositorySizeMin: 100000, PayloadRepositorySizeMax: 100000},
+			Want:      []*github.Event{events[3]},
+		},
+		{
+			Condition: Condition{PayloadRepositorySizeMin: 100000, Negate: true},
+			Want:      []*github.Event{events[1], events[2]},
 		},
 	}
 
 	for _, test := range tests {
+		var have []*github.Event
 		for _, event := range events {
 			if test.Condition.Matches(event) {
-				if !reflect.DeepEqual(event, test.Want) {
-					// Incorrectly matched
-					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
-				}
-			} else if reflect.DeepEqual(event, test.Want) {
-				// Incorrectly missed
-				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+				have = append(have, event)
 			}
 		}
+		if !reflect.DeepEqual(have, test.Want) {
+			t.Errorf("condition %+v matched %v events, want %v", test.Condition, len(have), len(test.Want))
+		}
 	}
 }
 
-func TestCondition_payloadIssueMilestoneTitle(t *testing.T) {
+func TestCondition_payloadIssueLabels(t *testing.T) {
 	var (
-		empty    = json.RawMessage(`{"issue":{"milestone":null}}`)
-		contains = json.RawMessage(`{"issue":{"milestone":{"Title":"title"}}}`)
+		both     = json.RawMessage(`{"action":"labeled","issue":{"labels":["Bug","help wanted","Priority"]}}`)
+		bug      = json.RawMessage(`{"action":"labeled","issue":{"labels":["bug"]}}`)
+		noLabels = json.RawMessage(`{"action":"opened","issue":{"number":1}}`)
+		invalid  = json.RawMessage(`{"action":"labeled","issue":{"labels":"bug"}}`)
 	)
 
 	events := []*github.Event{
-		{RawPayload: &empty},
-		{RawPayload: &contains},
+		{Type: github.String("IssuesEvent"), RawPayload: nil},
+		{Type: github.String("IssuesEvent"), RawPayload: &both},
+		{Type: github.String("IssuesEvent"), RawPayload: &bug},
+		{Type: github.String("IssuesEvent"), RawPayload: &noLabels},
+		{Type: github.String("IssuesEvent"), RawPayload: &invalid},
 	}
 
 	tests := []struct {
 		Condition Condition
-		Want      *github.Event
+		Want      []*github.Event
 	}{
 		{
-			Condition: Condition{PayloadIssueMilestoneTitle: "nomatch"},
-			Want:      nil,
+			Condition: Condition{PayloadIssueLabels: []string{"bug", "priority"}},
+			Want:      []*github.Event{events[1]},
 		},
 		{
-			Condition: Condition{PayloadIssueMilestoneTitle: "title"},
-			Want:      events[1],
+			Condition: Condition{PayloadIssueLabels: []string{"bug"}},
+			Want:      []*github.Event{events[1], events[2]},
+		},
+		{
+			Condition: Condition{PayloadIssueLabels: []string{"bug", "priority"}, Negate: true},
+			Want:      []*github.Event{events[2], events[3]},
+		},
+		{
+			Condition: Condition{PayloadIssueLabels: []string{"bug"}, CaseSensitive: true},
+			Want:      []*github.Event{events[2]},
+		},
+		{
+			Condition: Condition{PayloadIssueLabels: []string{"bug", "priority", "wontfix"}},
+			Want:      nil,
 		},
 	}
 
 	for _, test := range tests {
+		var have []*github.Event
 		for _, event := range events {
 			if test.Condition.Matches(event) {
-				if !reflect.DeepEqual(event, test.Want) {
-					// Incorrectly matched
-					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
-				}
-			} else if reflect.DeepEqual(event, test.Want) {
-				// Incorrectly missed
-				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+				have = append(have, event)
 			}
 		}
+		if !reflect.DeepEqual(have, test.Want) {
+			t.Errorf("condition %+v matched %v events, want %v", test.Condition, len(have), len(test.Want))
+		}
 	}
 }
 
-func TestCondition_payloadIssueTitleRegexp(t *testing.T) {
+func TestCondition_payloadSecurityAdvisorySeverity(t *testing.T) {
 	var (
-		match   = json.RawMessage(`{"issue":{"title":"This will Match"}}`)
-		nomatch = json.RawMessage(`{"issue":{"title":"This will Not Match"}}`)
+		critical = json.RawMessage(`{
+			"action": "published",
+			"security_advisory": {
+				"ghsa_id": "GHSA-rf4j-j272-fj86",
+				"summary": "Moderate severity vulnerability that affects Django",
+				"severity": "CRITICAL",
+				"identifiers": [{"value": "GHSA-rf4j-j272-fj86", "type": "GHSA"}],
+				"vulnerabilities": [{"package": {"ecosystem": "pip", "name": "django"}, "severity": "critical"}]
+			}
+		}`)
+		moderate   = json.RawMessage(`{"action":"published","security_advisory":{"severity":"moderate"}}`)
+		noAdvisory = json.RawMessage(`{"action":"published"}`)
 	)
 
 	events := []*github.Event{
-		{RawPayload: &match},
-		{RawPayload: &nomatch},
+		{Type: github.String("SecurityAdvisoryEvent"), RawPayload: nil},
+		{Type: github.String("SecurityAdvisoryEvent"), RawPayload: &critical},
+		{Type: github.String("SecurityAdvisoryEvent"), RawPayload: &moderate},
+		{Type: github.String("SecurityAdvisoryEvent"), RawPayload: &noAdvisory},
 	}
 
 	tests := []struct {
@@ -330,12 +4124,16 @@ func TestCondition_payloadIssueTitleRegexp(t *testing.T) {
 		Want      *github.Event
 	}{
 		{
-			Condition: Condition{PayloadIssueTitleRegexp: "not a match"},
-			Want:      nil,
+			Condition: Condition{PayloadSecurityAdvisorySeverity: "critical"},
+			Want:      events[1],
 		},
 		{
-			Condition: Condition{PayloadIssueTitleRegexp: `(?i)will\s+match`},
-			Want:      events[0],
+			Condition: Condition{PayloadSecurityAdvisorySeverity: "critical", Negate: true},
+			Want:      events[2],
+		},
+		{
+			Condition: Condition{PayloadSecurityAdvisorySeverity: "low"},
+			Want:      nil,
 		},
 	}
 
@@ -354,149 +4152,220 @@ func TestCondition_payloadIssueTitleRegexp(t *testing.T) {
 	}
 }
 
-func TestCondition_payloadIssueBodyRegexp(t *testing.T) {
+func TestCondition_payloadHasNoAction(t *testing.T) {
 	var (
-		match   = json.RawMessage(`{"issue":{"body":"This will Match"}}`)
-		nomatch = json.RawMessage(`{"issue":{"body":"This will Not Match"}}`)
+		push    = json.RawMessage(`{"ref":"refs/heads/main","commits":[{"message":"Update README"}]}`)
+		opened  = json.RawMessage(`{"action":"opened","issue":{"number":1}}`)
+		invalid = json.RawMessage(`{"action":`)
 	)
 
 	events := []*github.Event{
-		{RawPayload: &match},
-		{RawPayload: &nomatch},
+		{Type: github.String("PushEvent"), RawPayload: nil},
+		{Type: github.String("PushEvent"), RawPayload: &push},
+		{Type: github.String("IssuesEvent"), RawPayload: &opened},
+		{Type: github.String("IssuesEvent"), RawPayload: &invalid},
 	}
 
 	tests := []struct {
 		Condition Condition
-		Want      *github.Event
+		Want      []*github.Event
 	}{
 		{
-			Condition: Condition{PayloadIssueBodyRegexp: "not a match"},
-			Want:      nil,
+			Condition: Condition{PayloadHasNoAction: true},
+			Want:      events[:2],
 		},
 		{
-			Condition: Condition{PayloadIssueBodyRegexp: `(?i)will\s+match`},
-			Want:      events[0],
+			Condition: Condition{PayloadHasNoAction: true, Negate: true},
+			Want:      []*github.Event{events[2]},
+		},
+		{
+			Condition: Condition{PayloadHasNoAction: true, Type: "PushEvent"},
+			Want:      events[:2],
 		},
 	}
 
 	for _, test := range tests {
+		var have []*github.Event
 		for _, event := range events {
 			if test.Condition.Matches(event) {
-				if !reflect.DeepEqual(event, test.Want) {
-					// Incorrectly matched
-					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
-				}
-			} else if reflect.DeepEqual(event, test.Want) {
-				// Incorrectly missed
-				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+				have = append(have, event)
 			}
 		}
+		if !reflect.DeepEqual(have, test.Want) {
+			t.Errorf("condition %+v matched %v events, want %v", test.Condition, len(have), len(test.Want))
+		}
+	}
+
+	if _, err := (&Condition{PayloadHasNoAction: true}).Test(events[3]); err == nil {
+		t.Errorf("expected error testing invalid payload")
+	}
+
+	// A nil payload is treated as an event without a payload
+	noAction := Condition{PayloadHasNoAction: true}
+	if !noAction.MatchesWithPayload(events[0], nil) {
+		t.Errorf("expected nil payload to match")
+	}
+	if !noAction.MatchesWithPayload(events[0], map[string]interface{}{"ref": "refs/heads/main"}) {
+		t.Errorf("expected payload without action to match")
+	}
+	if noAction.MatchesWithPayload(events[0], map[string]interface{}{"action": "opened"}) {
+		t.Errorf("expected payload with action to not match")
+	}
+	hasAction := Condition{PayloadHasNoAction: true, Negate: true}
+	if hasAction.MatchesWithPayload(events[0], nil) {
+		t.Errorf("expected negated condition to not match nil payload")
 	}
 }
 
-func TestCondition_public(t *testing.T) {
+func TestCondition_created(t *testing.T) {
+	var (
+		after  = time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+		before = time.Date(2017, 2, 1, 0, 0, 0, 0, time.UTC)
+	)
+
 	events := []*github.Event{
-		{Public: github.Bool(true)},
-		{Public: github.Bool(false)},
+		{Type: github.String("PushEvent"), CreatedAt: timePtr(after.Add(-time.Hour))},
+		{Type: github.String("PushEvent"), CreatedAt: timePtr(after)},
+		{Type: github.String("PushEvent"), CreatedAt: timePtr(after.Add(time.Hour))},
+		{Type: github.String("PushEvent"), CreatedAt: timePtr(before.Add(time.Hour))},
+		{Type: github.String("PushEvent"), CreatedAt: nil},
 	}
 
 	tests := []struct {
 		Condition Condition
-		Want      *github.Event
+		Want      []*github.Event
 	}{
 		{
-			Condition: Condition{ComparePublic: true, Public: true},
-			Want:      events[0],
+			Condition: Condition{CreatedAfter: after, CreatedBefore: before},
+			Want:      []*github.Event{events[2]},
 		},
 		{
-			Condition: Condition{ComparePublic: true, Public: false},
-			Want:      events[1],
+			Condition: Condition{CreatedAfter: after},
+			Want:      events[2:4],
+		},
+		{
+			Condition: Condition{CreatedBefore: before},
+			Want:      events[:3],
+		},
+		{
+			Condition: Condition{CreatedAfter: after, CreatedBefore: before, Negate: true},
+			Want:      []*github.Event{events[0], events[1], events[3]},
+		},
+		{
+			Condition: Condition{},
+			Want:      events,
 		},
 	}
 
 	for _, test := range tests {
+		var have []*github.Event
 		for _, event := range events {
 			if test.Condition.Matches(event) {
-				if !reflect.DeepEqual(event, test.Want) {
-					// Incorrectly matched
-					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
-				}
-			} else if reflect.DeepEqual(event, test.Want) {
-				// Incorrectly missed
-				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+				have = append(have, event)
 			}
 		}
+		if !reflect.DeepEqual(have, test.Want) {
+			t.Errorf("condition %+v matched %v events, want %v", test.Condition, len(have), len(test.Want))
+		}
 	}
 }
 
-func TestCondition_organizationID(t *testing.T) {
+func TestCondition_payloadPullRequestRequestedReviewer(t *testing.T) {
+	var (
+		octocat = json.RawMessage(`{"action":"review_requested","pull_request":{"number":1},"requested_reviewer":{"login":"octocat"}}`)
+		hubot   = json.RawMessage(`{"action":"review_request_removed","pull_request":{"number":1},"requested_reviewer":{"login":"hubot"}}`)
+		team    = json.RawMessage(`{"action":"review_requested","pull_request":{"number":1},"requested_team":{"name":"core"}}`)
+	)
+
 	events := []*github.Event{
-		{Org: nil},
-		{Org: &github.Organization{ID: github.Int(1)}},
-		{Org: &github.Organization{ID: github.Int(2)}},
+		{Type: github.String("PullRequestEvent"), RawPayload: nil},
+		{Type: github.String("PullRequestEvent"), RawPayload: &octocat},
+		{Type: github.String("PullRequestEvent"), RawPayload: &hubot},
+		{Type: github.String("PullRequestEvent"), RawPayload: &team},
 	}
 
 	tests := []struct {
 		Condition Condition
-		Want      *github.Event
+		Want      []*github.Event
 	}{
 		{
-			Condition: Condition{OrganizationID: 1},
-			Want:      events[1],
+			Condition: Condition{PayloadPullRequestRequestedReviewer: "OctoCat"},
+			Want:      []*github.Event{events[1]},
 		},
 		{
-			Condition: Condition{OrganizationID: 2},
-			Want:      events[2],
+			Condition: Condition{PayloadPullRequestRequestedReviewer: "octocat", Negate: true},
+			Want:      []*github.Event{events[2]},
 		},
 	}
 
 	for _, test := range tests {
+		var have []*github.Event
 		for _, event := range events {
 			if test.Condition.Matches(event) {
-				if !reflect.DeepEqual(event, test.Want) {
-					// Incorrectly matched
-					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
-				}
-			} else if reflect.DeepEqual(event, test.Want) {
-				// Incorrectly missed
-				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+				have = append(have, event)
 			}
 		}
+		if !reflect.DeepEqual(have, test.Want) {
+			t.Errorf("condition %+v matched %v events, want %v", test.Condition, len(have), len(test.Want))
+		}
 	}
 }
 
-func TestCondition_repositoryID(t *testing.T) {
+func TestCondition_caseSensitive(t *testing.T) {
+	var (
+		upper = json.RawMessage(`{"action":"labeled","issue":{"labels":["WIP"],"milestone":{"title":"V1"}}}`)
+		lower = json.RawMessage(`{"action":"labeled","issue":{"labels":["wip"],"milestone":{"title":"v1"}}}`)
+	)
+
 	events := []*github.Event{
-		{Repo: nil},
-		{Repo: &github.Repository{ID: github.Int(1)}},
-		{Repo: &github.Repository{ID: github.Int(2)}},
+		{Type: github.String("IssuesEvent"), RawPayload: &upper},
+		{Type: github.String("IssuesEvent"), RawPayload: &lower},
 	}
 
 	tests := []struct {
 		Condition Condition
-		Want      *github.Event
+		Want      []*github.Event
 	}{
 		{
-			Condition: Condition{RepositoryID: 1},
-			Want:      events[1],
+			Condition: Condition{PayloadIssueLabel: "WIP"},
+			Want:      events,
 		},
 		{
-			Condition: Condition{RepositoryID: 2},
-			Want:      events[2],
+			Condition: Condition{PayloadIssueLabel: "WIP", CaseSensitive: true},
+			Want:      events[:1],
+		},
+		{
+			// A differently cased label is only excluded when case sensitive
+			Condition: Condition{PayloadIssueLabel: "WIP", CaseSensitive: true, Negate: true},
+			Want:      events[1:],
+		},
+		{
+			Condition: Condition{PayloadIssueLabel: "WIP", Negate: true},
+			Want:      nil,
+		},
+		{
+			Condition: Condition{PayloadIssueMilestoneTitle: "v1", CaseSensitive: true},
+			Want:      events[1:],
+		},
+		{
+			Condition: Condition{PayloadAction: "Labeled"},
+			Want:      events,
+		},
+		{
+			Condition: Condition{PayloadAction: "Labeled", CaseSensitive: true},
+			Want:      nil,
 		},
 	}
 
 	for _, test := range tests {
+		var have []*github.Event
 		for _, event := range events {
 			if test.Condition.Matches(event) {
-				if !reflect.DeepEqual(event, test.Want) {
-					// Incorrectly matched
-					t.Errorf("condition incorrectly matched\nevent: %+v\ncondition: %+v", event, test.Condition)
-				}
-			} else if reflect.DeepEqual(event, test.Want) {
-				// Incorrectly missed
-				t.Errorf("condition incorrectly missed\nevent: %+v\ncondition: %+v", event, test.Condition)
+				have = append(have, event)
 			}
 		}
+		if !reflect.DeepEqual(have, test.Want) {
+			t.Errorf("condition %+v matched %v events, want %v", test.Condition, len(have), len(test.Want))
+		}
 	}
 }
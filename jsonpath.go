@@ -0,0 +1,79 @@
+package ghfilter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// JSONPathBool is a boolean field in an event's payload, such as
+// "pull_request.draft", used by a condition's PayloadJSONPathBool.
+type JSONPathBool struct {
+	// Path is a dot separated list of object keys, such as "pull_request.draft".
+	Path string
+	// Value is the field's expected value. A missing field, or a field which is
+	// not a boolean, is false.
+	Value bool
+}
+
+// matches returns true if the field at Path in payload, a payload decoded into
+// an interface{}, equals Value.
+func (b *JSONPathBool) matches(payload interface{}) bool {
+	value, _ := lookupPath(payload, b.Path)
+	actual, _ := value.(bool)
+	return actual == b.Value
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, encoding the
+// field such as "pull_request.draft=true".
+func (b *JSONPathBool) MarshalText() ([]byte, error) {
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+	return []byte(b.Path + "=" + strconv.FormatBool(b.Value)), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, decoding a
+// field encoded by MarshalText.
+func (b *JSONPathBool) UnmarshalText(text []byte) error {
+	eq := strings.LastIndexByte(string(text), '=')
+	if eq < 0 {
+		return fmt.Errorf("invalid path %q, expected path=value", text)
+	}
+	value, err := strconv.ParseBool(string(text[eq+1:]))
+	if err != nil {
+		return fmt.Errorf("invalid path %q, expected path=value", text)
+	}
+	path := JSONPathBool{Path: string(text[:eq]), Value: value}
+	if err := path.validate(); err != nil {
+		return err
+	}
+	*b = path
+	return nil
+}
+
+// validate returns an error if Path is empty or contains an empty key.
+func (b *JSONPathBool) validate() error {
+	for _, key := range strings.Split(b.Path, ".") {
+		if key == "" {
+			return fmt.Errorf("path %q must not contain an empty key", b.Path)
+		}
+	}
+	return nil
+}
+
+// lookupPath returns the value at path, a dot separated list of object keys,
+// in v, a payload decoded into an interface{}. False is returned if any key in
+// path does not exist.
+func lookupPath(v interface{}, path string) (interface{}, bool) {
+	for _, key := range strings.Split(path, ".") {
+		object, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if v, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
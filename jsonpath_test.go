@@ -0,0 +1,84 @@
+package ghfilter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+func TestCondition_payloadJSONPathBool(t *testing.T) {
+	var (
+		draft     = json.RawMessage(`{"action":"opened","pull_request":{"draft":true}}`)
+		ready     = json.RawMessage(`{"action":"opened","pull_request":{"draft":false}}`)
+		missing   = json.RawMessage(`{"action":"opened","pull_request":{}}`)
+		notBool   = json.RawMessage(`{"action":"opened","pull_request":{"draft":"true"}}`)
+		notObject = json.RawMessage(`{"action":"opened","pull_request":[]}`)
+	)
+
+	tests := []struct {
+		path    *JSONPathBool
+		payload *json.RawMessage
+		want    bool
+	}{
+		{path: &JSONPathBool{Path: "pull_request.draft", Value: true}, payload: nil, want: false},
+		{path: &JSONPathBool{Path: "pull_request.draft", Value: false}, payload: nil, want: false},
+		{path: &JSONPathBool{Path: "pull_request.draft", Value: true}, payload: &draft, want: true},
+		{path: &JSONPathBool{Path: "pull_request.draft", Value: true}, payload: &ready, want: false},
+		{path: &JSONPathBool{Path: "pull_request.draft", Value: true}, payload: &missing, want: false},
+		{path: &JSONPathBool{Path: "pull_request.draft", Value: false}, payload: &draft, want: false},
+		{path: &JSONPathBool{Path: "pull_request.draft", Value: false}, payload: &ready, want: true},
+		{path: &JSONPathBool{Path: "pull_request.draft", Value: false}, payload: &missing, want: true},
+		{path: &JSONPathBool{Path: "pull_request.draft", Value: false}, payload: &notBool, want: true},
+		{path: &JSONPathBool{Path: "pull_request.draft", Value: false}, payload: &notObject, want: true},
+		{path: &JSONPathBool{Path: "pull_request", Value: false}, payload: &draft, want: true},
+	}
+
+	for _, test := range tests {
+		condition := Condition{PayloadJSONPathBool: test.path}
+		have := condition.Matches(&github.Event{RawPayload: test.payload})
+		if have != test.want {
+			payload := "nil"
+			if test.payload != nil {
+				payload = string(*test.payload)
+			}
+			t.Errorf("path %+v payload %s have: %v, want: %v", test.path, payload, have, test.want)
+		}
+	}
+
+	// Payloads provided by the caller are compared by their JSON encoding
+	condition := Condition{PayloadJSONPathBool: &JSONPathBool{Path: "pull_request.draft", Value: true}}
+	payload := map[string]interface{}{"pull_request": map[string]bool{"draft": true}}
+	if !condition.MatchesWithPayload(&github.Event{}, payload) {
+		t.Errorf("condition did not match provided payload %v", payload)
+	}
+	negated := condition.WithNegate(true)
+	if negated.MatchesWithPayload(&github.Event{RawPayload: &draft}, nil) {
+		t.Errorf("negated condition matched nil provided payload")
+	}
+}
+
+func TestJSONPathBool_text(t *testing.T) {
+	condition := Condition{PayloadJSONPathBool: &JSONPathBool{Path: "pull_request.draft", Value: true}}
+	text, err := condition.MarshalText()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `PayloadJSONPathBool="pull_request.draft=true"`; string(text) != want {
+		t.Errorf("have: %s, want: %s", text, want)
+	}
+
+	var decoded Condition
+	if err := decoded.UnmarshalText(text); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *decoded.PayloadJSONPathBool != *condition.PayloadJSONPathBool {
+		t.Errorf("have: %+v, want: %+v", decoded.PayloadJSONPathBool, condition.PayloadJSONPathBool)
+	}
+
+	for _, invalid := range []string{"", "pull_request.draft", "pull_request.draft=yes", "=true", "pull_request..draft=true"} {
+		if err := new(JSONPathBool).UnmarshalText([]byte(invalid)); err == nil {
+			t.Errorf("expected error decoding %q", invalid)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package ghfilter
+
+import "container/list"
+
+// DefaultCacheCapacity is the default maximum number of keys remembered by
+// filters which track previously received events, such as DedupFilter.
+const DefaultCacheCapacity = 10000
+
+// lruCache maps keys to values, remembering at most capacity keys by evicting
+// the least recently set key. It is not safe for concurrent use.
+type lruCache struct {
+	capacity int
+	order    *list.List // order contains *lruEntry, most recently set first
+	entries  map[interface{}]*list.Element
+}
+
+// lruEntry is a key and its value in an lruCache.
+type lruEntry struct {
+	key, value interface{}
+}
+
+// newLRUCache returns an lruCache remembering capacity keys, a capacity less
+// than 1 remembers a single key.
+func newLRUCache(capacity int) *lruCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &lruCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[interface{}]*list.Element),
+	}
+}
+
+// get returns the value of key, or false if key is not in the cache.
+func (c *lruCache) get(key interface{}) (interface{}, bool) {
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	return elem.Value.(*lruEntry).value, true
+}
+
+// set sets the value of key and marks it as the most recently set, evicting
+// the least recently set key if the cache is full.
+func (c *lruCache) set(key, value interface{}) {
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*lruEntry).value = value
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value})
+	if c.order.Len() > c.capacity {
+		c.remove(c.order.Back().Value.(*lruEntry).key)
+	}
+}
+
+// remove removes key from the cache.
+func (c *lruCache) remove(key interface{}) {
+	if elem, ok := c.entries[key]; ok {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+	}
+}
+
+// removeOldest removes the least recently set keys while expired returns true
+// for their value, so the cost of removing expired keys is proportional to the
+// number removed, rather than the number of keys.
+func (c *lruCache) removeOldest(expired func(value interface{}) bool) {
+	for elem := c.order.Back(); elem != nil; elem = c.order.Back() {
+		entry := elem.Value.(*lruEntry)
+		if !expired(entry.value) {
+			return
+		}
+		c.remove(entry.key)
+	}
+}
+
+// len returns the number of keys in the cache.
+func (c *lruCache) len() int {
+	return c.order.Len()
+}
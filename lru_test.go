@@ -0,0 +1,31 @@
+package ghfilter
+
+import "testing"
+
+func TestLRUCache(t *testing.T) {
+	cache := newLRUCache(2)
+	cache.set("a", 1)
+	cache.set("b", 2)
+	cache.set("a", 3) // a is now the most recently set
+	cache.set("c", 4) // b is evicted
+
+	if _, ok := cache.get("b"); ok {
+		t.Errorf("expected b to be evicted")
+	}
+	if value, ok := cache.get("a"); !ok || value != 3 {
+		t.Errorf("a have: %v, %v want: 3, true", value, ok)
+	}
+
+	cache.removeOldest(func(value interface{}) bool {
+		return value.(int) < 4
+	})
+	if _, ok := cache.get("a"); ok {
+		t.Errorf("expected a to be removed")
+	}
+	if value, ok := cache.get("c"); !ok || value != 4 {
+		t.Errorf("c have: %v, %v want: 4, true", value, ok)
+	}
+	if have := cache.len(); have != 1 {
+		t.Errorf("have %v keys, want 1", have)
+	}
+}
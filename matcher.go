@@ -0,0 +1,44 @@
+package ghfilter
+
+import "github.com/google/go-github/github"
+
+// Matcher is implemented by types which test whether an event matches, such as
+// *Condition, *Filter and *CompiledFilter.
+type Matcher interface {
+	Matches(event *github.Event) bool
+}
+
+// MatcherFunc is an adapter to allow the use of an ordinary function as a
+// Matcher.
+type MatcherFunc func(event *github.Event) bool
+
+// Matches returns f(event).
+func (f MatcherFunc) Matches(event *github.Event) bool {
+	return f(event)
+}
+
+// All returns a Matcher which matches if all matchers match, evaluated in order
+// until one does not match. If no matchers are provided, it always matches.
+func All(matchers ...Matcher) Matcher {
+	return MatcherFunc(func(event *github.Event) bool {
+		for _, m := range matchers {
+			if !m.Matches(event) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// Any returns a Matcher which matches if any matcher matches, evaluated in
+// order until one matches. If no matchers are provided, it never matches.
+func Any(matchers ...Matcher) Matcher {
+	return MatcherFunc(func(event *github.Event) bool {
+		for _, m := range matchers {
+			if m.Matches(event) {
+				return true
+			}
+		}
+		return false
+	})
+}
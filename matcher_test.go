@@ -0,0 +1,47 @@
+package ghfilter
+
+import (
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+func TestAllAny(t *testing.T) {
+	var (
+		issues = &Condition{Type: "IssuesEvent"}
+		public = &Filter{Conditions: []Condition{{ComparePublic: true, Public: true}}}
+		repo1  = MatcherFunc(func(event *github.Event) bool {
+			return event.Repo != nil && event.Repo.GetID() == 1
+		})
+	)
+
+	var (
+		publicIssue  = &github.Event{Type: github.String("IssuesEvent"), Public: github.Bool(true)}
+		privateIssue = &github.Event{Type: github.String("IssuesEvent"), Public: github.Bool(false)}
+		repo1Push    = &github.Event{Type: github.String("PushEvent"), Repo: &github.Repository{ID: github.Int(1)}}
+		otherPush    = &github.Event{Type: github.String("PushEvent")}
+	)
+
+	tests := []struct {
+		matcher Matcher
+		event   *github.Event
+		want    bool
+	}{
+		{All(), otherPush, true},
+		{Any(), otherPush, false},
+		{All(issues, public), publicIssue, true},
+		{All(issues, public), privateIssue, false},
+		{Any(issues, repo1), privateIssue, true},
+		{Any(issues, repo1), repo1Push, true},
+		{Any(issues, repo1), otherPush, false},
+		{Any(All(issues, public), repo1), publicIssue, true},
+		{Any(All(issues, public), repo1), privateIssue, false},
+		{All(Any(issues, repo1), public), repo1Push, false},
+	}
+
+	for i, test := range tests {
+		if have := test.matcher.Matches(test.event); have != test.want {
+			t.Errorf("test %d have: %v, want: %v", i, have, test.want)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package ghfilter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+// Node is a node in a tree of conditions, such as a Filter's Root, which is
+// either a *Condition leaf or a *CompositeNode.
+type Node interface {
+	Matcher
+	// matches is like Matches, now is only called if a time based test is set.
+	matches(event *github.Event, now func() time.Time) bool
+	// handlesType returns false if the node cannot match events of eventType.
+	handlesType(eventType string) bool
+	description() string
+}
+
+var (
+	_ Node = &Condition{}
+	_ Node = &CompositeNode{}
+)
+
+// CompositeNode is a Node combining its children using Logic, allowing
+// expressions such as "(A AND B) OR (C AND NOT D)". Children are evaluated in
+// order until the result is known. A CompositeNode without children matches if
+// Logic is And, and does not match if Logic is Or.
+type CompositeNode struct {
+	Logic    Logic  `json:"Logic,omitempty"`
+	Children []Node `json:"Children"`
+}
+
+// NewAnd returns a CompositeNode requiring all children to match.
+func NewAnd(children ...Node) *CompositeNode {
+	return &CompositeNode{Logic: And, Children: children}
+}
+
+// NewOr returns a CompositeNode requiring any child to match.
+func NewOr(children ...Node) *CompositeNode {
+	return &CompositeNode{Logic: Or, Children: children}
+}
+
+// Matches returns true if the children, combined using Logic, match event.
+func (n *CompositeNode) Matches(event *github.Event) bool {
+	return n.matches(event, time.Now)
+}
+
+func (n *CompositeNode) matches(event *github.Event, now func() time.Time) bool {
+	return n.combine(func(child Node) bool {
+		return child.matches(event, now)
+	})
+}
+
+func (n *CompositeNode) handlesType(eventType string) bool {
+	return n.combine(func(child Node) bool {
+		return child.handlesType(eventType)
+	})
+}
+
+// combine combines the result of calling fn with each child using Logic,
+// stopping as soon as the result is known.
+func (n *CompositeNode) combine(fn func(Node) bool) bool {
+	if n.Logic == Or {
+		for _, child := range n.Children {
+			if fn(child) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, child := range n.Children {
+		if !fn(child) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns a human readable description of the node, such as
+// `If (type is "IssuesEvent") OR (payload action is "opened")`.
+func (n *CompositeNode) String() string {
+	return "If " + n.description()
+}
+
+// description returns the children's descriptions combined using Logic. A node
+// without children, which matches every event if Logic is And or no events if
+// Logic is Or, is described as "always" or "never".
+func (n *CompositeNode) description() string {
+	switch len(n.Children) {
+	case 0:
+		if n.Logic == Or {
+			return "never"
+		}
+		return "always"
+	case 1:
+		return n.Children[0].description()
+	}
+	descriptions := make([]string, len(n.Children))
+	for i, child := range n.Children {
+		descriptions[i] = "(" + child.description() + ")"
+	}
+	return strings.Join(descriptions, " "+strings.ToUpper(n.Logic.String())+" ")
+}
+
+// conditions calls fn with each Condition leaf in the tree, in order.
+func (n *CompositeNode) conditions(fn func(*Condition)) {
+	for _, child := range n.Children {
+		switch child := child.(type) {
+		case *Condition:
+			fn(child)
+		case *CompositeNode:
+			child.conditions(fn)
+		}
+	}
+}
+
+// checkRegexps returns an error if any condition in the tree contains an
+// invalid regular expression.
+func (n *CompositeNode) checkRegexps() error {
+	var err error
+	n.conditions(func(condition *Condition) {
+		if err == nil {
+			err = condition.checkRegexps()
+		}
+	})
+	return err
+}
+
+// clone returns a deep copy of the node.
+func (n *CompositeNode) clone() *CompositeNode {
+	clone := &CompositeNode{Logic: n.Logic, Children: make([]Node, len(n.Children))}
+	for i, child := range n.Children {
+		switch child := child.(type) {
+		case *Condition:
+			condition := child.clone()
+			clone.Children[i] = &condition
+		case *CompositeNode:
+			clone.Children[i] = child.clone()
+		}
+	}
+	return clone
+}
+
+// jsonCompositeNode is the JSON encoding of a CompositeNode.
+type jsonCompositeNode struct {
+	Logic    Logic             `json:"Logic"`
+	Children []json.RawMessage `json:"Children"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Each child is
+// decoded as a CompositeNode if it has a Children field, else as a Condition.
+func (n *CompositeNode) UnmarshalJSON(data []byte) error {
+	var decoded jsonCompositeNode
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	n.Logic = decoded.Logic
+	n.Children = make([]Node, len(decoded.Children))
+	for i, data := range decoded.Children {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return fmt.Errorf("child %d: %v", i, err)
+		}
+		var child Node = &Condition{}
+		if _, ok := fields["Children"]; ok {
+			child = &CompositeNode{}
+		}
+		if err := json.Unmarshal(data, child); err != nil {
+			return fmt.Errorf("child %d: %v", i, err)
+		}
+		n.Children[i] = child
+	}
+	return nil
+}
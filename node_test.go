@@ -0,0 +1,134 @@
+package ghfilter
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+func TestCompositeNode_matches(t *testing.T) {
+	var (
+		results   map[string]bool
+		evaluated []string
+	)
+	// leaf returns a condition whose result is results[name], recording the
+	// order conditions are evaluated in.
+	leaf := func(name string, negate bool) *Condition {
+		return &Condition{Negate: negate, ActorAllowed: func(string) bool {
+			evaluated = append(evaluated, name)
+			return results[name]
+		}}
+	}
+	// (A AND B) OR (C AND (NOT D OR E))
+	root := NewOr(
+		NewAnd(leaf("A", false), leaf("B", false)),
+		NewAnd(
+			leaf("C", false),
+			NewOr(leaf("D", true), leaf("E", false)),
+		),
+	)
+
+	tests := []struct {
+		results   map[string]bool
+		want      bool
+		evaluated []string
+	}{
+		{results: map[string]bool{"A": true, "B": true}, want: true, evaluated: []string{"A", "B"}},
+		{results: map[string]bool{"A": false}, want: false, evaluated: []string{"A", "C"}},
+		{results: map[string]bool{"A": true, "C": true}, want: true, evaluated: []string{"A", "B", "C", "D"}},
+		{results: map[string]bool{"C": true, "D": true}, want: false, evaluated: []string{"A", "C", "D", "E"}},
+		{results: map[string]bool{"C": true, "D": true, "E": true}, want: true, evaluated: []string{"A", "C", "D", "E"}},
+	}
+
+	event := &github.Event{Actor: &github.User{Login: github.String("bradleyfalzon")}}
+	for _, test := range tests {
+		results, evaluated = test.results, nil
+		have := root.Matches(event)
+		if have != test.want {
+			t.Errorf("results %v have: %v, want: %v", test.results, have, test.want)
+		}
+		if !reflect.DeepEqual(evaluated, test.evaluated) {
+			t.Errorf("results %v evaluated: %v, want: %v", test.results, evaluated, test.evaluated)
+		}
+	}
+}
+
+func TestCompositeNode_empty(t *testing.T) {
+	event := &github.Event{Type: github.String("IssuesEvent")}
+	if !NewAnd().Matches(event) {
+		t.Errorf("expected empty and to match")
+	}
+	if NewOr().Matches(event) {
+		t.Errorf("expected empty or to not match")
+	}
+
+	tests := []struct {
+		node *CompositeNode
+		want string
+	}{
+		{NewAnd(), "If always"},
+		{NewOr(), "If never"},
+		{NewOr(&Condition{Type: "IssuesEvent"}, NewAnd()), `If (type is "IssuesEvent") OR (always)`},
+		{NewAnd(&Condition{Type: "IssuesEvent"}, NewOr()), `If (type is "IssuesEvent") AND (never)`},
+	}
+	for _, test := range tests {
+		if have := test.node.String(); have != test.want {
+			t.Errorf("have: %q, want: %q", have, test.want)
+		}
+	}
+
+	filter := Filter{Conditions: []Condition{{Type: "IssuesEvent"}}, Root: NewOr()}
+	if have, want := filter.String(), `If (type is "IssuesEvent") AND (never)`; have != want {
+		t.Errorf("have: %q, want: %q", have, want)
+	}
+}
+
+func TestFilter_root(t *testing.T) {
+	filter := Filter{
+		Conditions: []Condition{{ComparePublic: true, Public: true}},
+		Root: NewOr(
+			&Condition{Type: "IssuesEvent"},
+			NewAnd(&Condition{Type: "PushEvent"}, &Condition{RepositoryID: 1}),
+		),
+	}
+
+	tests := []struct {
+		event *github.Event
+		want  bool
+	}{
+		{event: &github.Event{Type: github.String("IssuesEvent"), Public: github.Bool(true)}, want: true},
+		{event: &github.Event{Type: github.String("IssuesEvent"), Public: github.Bool(false)}, want: false},
+		{event: &github.Event{Type: github.String("PushEvent"), Public: github.Bool(true), Repo: &github.Repository{ID: github.Int(1)}}, want: true},
+		{event: &github.Event{Type: github.String("PushEvent"), Public: github.Bool(true), Repo: &github.Repository{ID: github.Int(2)}}, want: false},
+		{event: &github.Event{Type: github.String("ForkEvent"), Public: github.Bool(true)}, want: false},
+	}
+
+	for _, test := range tests {
+		if have := filter.Matches(test.event); have != test.want {
+			t.Errorf("event %v have: %v, want: %v", *test.event.Type, have, test.want)
+		}
+	}
+
+	if !filter.HandlesType("PushEvent") || filter.HandlesType("ForkEvent") {
+		t.Errorf("unexpected handled types for root")
+	}
+
+	want := `If (type is "IssuesEvent") OR ((type is "PushEvent") AND (repository ID is 1))`
+	if have := filter.Root.String(); have != want {
+		t.Errorf("have: %s\nwant: %s", have, want)
+	}
+
+	data, err := json.Marshal(filter)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded Filter
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(decoded.Root, filter.Root) {
+		t.Errorf("decoded root does not match\nhave: %v\nwant: %v", decoded.Root, filter.Root)
+	}
+}
@@ -0,0 +1,62 @@
+package ghfilter
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/go-github/github"
+)
+
+// MatchesParallelBatch evaluates the filter against each event concurrently
+// using workers goroutines, a workers value less than 1 uses a single worker.
+// The returned results are aligned to events, such that results[i] is the
+// result of Matches(events[i]), regardless of the order of evaluation.
+//
+// If ctx is cancelled before all events have been evaluated, ctx's error is
+// returned with nil results. If ctx is cancelled after all events have been
+// evaluated, the results are returned without error. Any Funcs must be safe
+// for concurrent use.
+func (f *Filter) MatchesParallelBatch(ctx context.Context, events []*github.Event, workers int) ([]bool, error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	var (
+		results = make([]bool, len(events))
+		indexes = make(chan int)
+		wg      sync.WaitGroup
+	)
+
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = f.Matches(events[i])
+			}
+		}()
+	}
+
+	// Every index sent is evaluated, so ctx's error is only returned if an
+	// index was not sent.
+	var err error
+loop:
+	for i := range events {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			err = ctx.Err()
+			break loop
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
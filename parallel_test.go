@@ -0,0 +1,101 @@
+package ghfilter
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+func TestFilter_matchesParallelBatch(t *testing.T) {
+	filter := Filter{
+		Conditions: []Condition{
+			{Type: "IssuesEvent"},
+		},
+	}
+
+	var (
+		events []*github.Event
+		want   []bool
+	)
+	for i := 0; i < 100; i++ {
+		eventType := "PushEvent"
+		if i%3 == 0 {
+			eventType = "IssuesEvent"
+		}
+		events = append(events, &github.Event{Type: github.String(eventType)})
+		want = append(want, i%3 == 0)
+	}
+
+	have, err := filter.MatchesParallelBatch(context.Background(), events, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("results do not align with events\nhave: %v\nwant: %v", have, want)
+	}
+}
+
+func TestFilter_matchesParallelBatchCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	filter := Filter{
+		Funcs: []func(*github.Event) bool{
+			func(*github.Event) bool {
+				cancel()
+				return true
+			},
+		},
+	}
+
+	events := make([]*github.Event, 100)
+	for i := range events {
+		events[i] = &github.Event{}
+	}
+
+	have, err := filter.MatchesParallelBatch(ctx, events, 1)
+	if err != context.Canceled {
+		t.Errorf("have error: %v, want: %v", err, context.Canceled)
+	}
+	if have != nil {
+		t.Errorf("expected nil results, have: %v", have)
+	}
+}
+
+func TestFilter_matchesParallelBatchCancelAfterLast(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make([]*github.Event, 10)
+	for i := range events {
+		events[i] = &github.Event{}
+	}
+	last := events[len(events)-1]
+
+	// Cancel while evaluating the last event, after every event has been sent
+	// to a worker
+	filter := Filter{
+		Funcs: []func(*github.Event) bool{
+			func(event *github.Event) bool {
+				if event == last {
+					cancel()
+				}
+				return true
+			},
+		},
+	}
+
+	have, err := filter.MatchesParallelBatch(ctx, events, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(have) != len(events) {
+		t.Fatalf("have %v results, want %v", len(have), len(events))
+	}
+	for i, matched := range have {
+		if !matched {
+			t.Errorf("result %d have: false, want: true", i)
+		}
+	}
+}
@@ -0,0 +1,441 @@
+package ghfilter
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/google/go-github/github"
+)
+
+// errNoPayload is returned when a payload is required but not provided.
+var errNoPayload = errors.New("no payload")
+
+// decodedPayload contains the fields of an event's payload used by conditions. Any
+// field added here is decoded for every event with a payload condition, so
+// fields which commonly differ in type between event types should be decoded
+// as json.RawMessage and only unmarshalled when required.
+//
+// If a field has an unexpected type, each top level field is decoded
+// separately, see decodePayloadFields, so only tests using the invalid top level
+// field fail.
+type decodedPayload struct {
+	Action string `json:"action"`
+	Number int    `json:"number"`
+	Ref    string `json:"ref"`
+	Issue  struct {
+		Number int `json:"number"`
+		// Labels is decoded when required to avoid a label payload of an
+		// unexpected type preventing other fields from being compared.
+		Labels    json.RawMessage `json:"labels"`
+		Milestone struct {
+			Title string `json:"title"`
+			DueOn string `json:"due_on"`
+		} `json:"milestone"`
+		Title             string `json:"title"`
+		Body              string `json:"body"`
+		AuthorAssociation string `json:"author_association"`
+		Locked            bool   `json:"locked"`
+		State             string `json:"state"`
+	} `json:"issue"`
+	Label struct {
+		Name string `json:"name"`
+	} `json:"label"`
+	Reaction struct {
+		Content string `json:"content"`
+	} `json:"reaction"`
+	// Comment is nil if the payload does not have a comment.
+	Comment *struct {
+		Path string `json:"path"`
+		Body string `json:"body"`
+	} `json:"comment"`
+	Membership struct {
+		User struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"membership"`
+	// PullRequest is nil if the payload does not have a pull_request.
+	PullRequest *struct {
+		Number    int   `json:"number"`
+		Commits   int   `json:"commits"`
+		Merged    *bool `json:"merged"`
+		Milestone *struct {
+			Title string `json:"title"`
+		} `json:"milestone"`
+		Head struct {
+			Ref  string `json:"ref"`
+			Repo struct {
+				FullName string `json:"full_name"`
+			} `json:"repo"`
+		} `json:"head"`
+		Base struct {
+			Ref  string `json:"ref"`
+			Repo struct {
+				FullName string `json:"full_name"`
+			} `json:"repo"`
+		} `json:"base"`
+	} `json:"pull_request"`
+	Repository struct {
+		Topics          []string `json:"topics"`
+		Archived        bool     `json:"archived"`
+		StargazersCount *int     `json:"stargazers_count"`
+		Size            *int     `json:"size"`
+		HTMLURL         string   `json:"html_url"`
+		Owner           struct {
+			Login string `json:"login"`
+		} `json:"owner"`
+	} `json:"repository"`
+	HeadCommit struct {
+		Timestamp string `json:"timestamp"`
+	} `json:"head_commit"`
+	// Commits is nil if the payload does not have a commits field.
+	Commits []struct {
+		Author struct {
+			Email string `json:"email"`
+		} `json:"author"`
+		Message  string   `json:"message"`
+		Added    []string `json:"added"`
+		Modified []string `json:"modified"`
+		Removed  []string `json:"removed"`
+	} `json:"commits"`
+	DeploymentStatus struct {
+		State string `json:"state"`
+	} `json:"deployment_status"`
+	WorkflowJob struct {
+		Conclusion string   `json:"conclusion"`
+		Labels     []string `json:"labels"`
+	} `json:"workflow_job"`
+	SecurityAdvisory struct {
+		Severity string `json:"severity"`
+	} `json:"security_advisory"`
+	Package struct {
+		PackageType string `json:"package_type"`
+		Ecosystem   string `json:"ecosystem"`
+	} `json:"package"`
+	Project struct {
+		Name string `json:"name"`
+	} `json:"project"`
+	Sender struct {
+		ID    int    `json:"id"`
+		Login string `json:"login"`
+	} `json:"sender"`
+	RequestedReviewer struct {
+		Login string `json:"login"`
+	} `json:"requested_reviewer"`
+	Changes struct {
+		Body *struct {
+			From string `json:"from"`
+		} `json:"body"`
+		NewIssue      *struct{} `json:"new_issue"`
+		NewRepository *struct{} `json:"new_repository"`
+	} `json:"changes"`
+	Created bool `json:"created"`
+	Deleted bool `json:"deleted"`
+}
+
+// lazyPayload decodes an event's payload at most once, when first required.
+type lazyPayload struct {
+	event     *github.Event
+	decoded   bool
+	payload   *decodedPayload // payload is allocated when decoded, so lazyPayload need not escape
+	decodeErr error           // decodeErr is the error decoding the entire payload
+	// invalid contains the errors decoding top level fields of the payload,
+	// keyed by their json name, such as "issue".
+	invalid map[string]error
+	// err is the reason the most recent call to get returned false, or nil if
+	// the event does not have a payload.
+	err error
+
+	// source is the payload provided to MatchesWithPayload, used instead of
+	// the event's RawPayload if hasSource is set.
+	source    interface{}
+	hasSource bool
+
+	treeDecoded bool
+	tree        interface{}
+	treeErr     error
+}
+
+// get returns the event's decoded payload, or false if the event does not
+// have a payload, it could not be decoded or any of the top level fields named
+// by fields, such as "issue", could not be decoded.
+func (p *lazyPayload) get(fields ...string) (*decodedPayload, bool) {
+	if !p.decoded {
+		if p.event.RawPayload == nil {
+			return nil, false
+		}
+		p.decoded = true
+		p.payload = new(decodedPayload)
+		p.decodeErr = json.Unmarshal(*p.event.RawPayload, p.payload)
+		if _, ok := p.decodeErr.(*json.UnmarshalTypeError); ok {
+			p.payload = new(decodedPayload)
+			p.invalid, p.decodeErr = decodePayloadFields(*p.event.RawPayload, p.payload)
+		}
+	}
+	if p.err = p.decodeErr; p.err != nil {
+		return nil, false
+	}
+	for _, field := range fields {
+		if p.err = p.invalid[field]; p.err != nil {
+			return nil, false
+		}
+	}
+	return p.payload, true
+}
+
+// decodePayloadFields decodes each top level field of data into the matching
+// field of dst separately, returning the errors decoding each field keyed by
+// its json name. Fields which could not be decoded are left empty.
+func decodePayloadFields(data []byte, dst *decodedPayload) (map[string]error, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return decodeEachField(dst, func(name string, field reflect.Value) error {
+		raw, ok := fields[name]
+		if !ok {
+			return nil
+		}
+		return json.Unmarshal(raw, field.Addr().Interface())
+	}), nil
+}
+
+// fillPayloadFields is like fillPayload but fills each top level field of dst
+// separately, returning the errors filling each field keyed by its json name.
+// Fields which could not be filled are left empty.
+func fillPayloadFields(dst *decodedPayload, src reflect.Value) (map[string]error, error) {
+	for src.Kind() == reflect.Ptr || src.Kind() == reflect.Interface {
+		if src.IsNil() {
+			return nil, nil
+		}
+		src = src.Elem()
+	}
+	fields, err := payloadFields(src)
+	if err != nil {
+		return nil, err
+	}
+	return decodeEachField(dst, func(name string, field reflect.Value) error {
+		value, ok := fields[name]
+		if !ok {
+			return nil
+		}
+		return fillPayload(field, value)
+	}), nil
+}
+
+// decodeEachField calls decode with each field of dst and its json name,
+// resetting fields which could not be decoded and returning their errors
+// keyed by json name.
+func decodeEachField(dst *decodedPayload, decode func(name string, field reflect.Value) error) map[string]error {
+	var (
+		invalid map[string]error
+		v       = reflect.ValueOf(dst).Elem()
+	)
+	for i := 0; i < v.NumField(); i++ {
+		name := jsonName(v.Type().Field(i))
+		if err := decode(name, v.Field(i)); err != nil {
+			if invalid == nil {
+				invalid = make(map[string]error)
+			}
+			invalid[name] = fmt.Errorf("%s: %v", name, err)
+			v.Field(i).Set(reflect.Zero(v.Field(i).Type()))
+		}
+	}
+	return invalid
+}
+
+// getTree returns the event's payload decoded into an interface{}, such as for
+// path lookups, or false if the event does not have a payload or it could not
+// be decoded.
+func (p *lazyPayload) getTree() (interface{}, bool) {
+	if !p.treeDecoded {
+		var data []byte
+		switch {
+		case p.hasSource && p.source == nil, !p.hasSource && p.event.RawPayload == nil:
+			return nil, false
+		case p.hasSource:
+			data, p.treeErr = json.Marshal(p.source)
+		default:
+			data = *p.event.RawPayload
+		}
+		p.treeDecoded = true
+		if p.treeErr == nil {
+			var tree interface{} // not &p.tree, so lazyPayload need not escape
+			p.treeErr = json.Unmarshal(data, &tree)
+			p.tree = tree
+		}
+	}
+	if p.treeErr != nil {
+		return nil, false
+	}
+	return p.tree, true
+}
+
+// number returns the issue or pull request number the payload refers to, or 0
+// if the payload does not refer to an issue or pull request.
+func (p *decodedPayload) number() int {
+	switch {
+	case p.Issue.Number != 0:
+		return p.Issue.Number
+	case p.PullRequest != nil && p.PullRequest.Number != 0:
+		return p.PullRequest.Number
+	}
+	return p.Number
+}
+
+// issueLabels returns the payload's issue labels, or false if the labels could
+// not be decoded. An issue without labels returns no labels.
+func (p *decodedPayload) issueLabels() ([]string, bool) {
+	var labels []string
+	if len(p.Issue.Labels) > 0 {
+		if err := json.Unmarshal(p.Issue.Labels, &labels); err != nil {
+			return nil, false
+		}
+	}
+	return labels, true
+}
+
+// comment returns the payload's comment path and body, which are empty if the
+// payload does not have a comment.
+func (p *decodedPayload) comment() (path, body string) {
+	if p.Comment == nil {
+		return "", ""
+	}
+	return p.Comment.Path, p.Comment.Body
+}
+
+var (
+	rawMessageType    = reflect.TypeOf(json.RawMessage(nil))
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// fillPayload sets the fields of dst from src, a payload already unmarshalled
+// by the caller, matching struct fields and map keys by their json name. Fields
+// in src which do not exist in dst are ignored, and fields in dst which do not
+// exist in src are left unchanged.
+func fillPayload(dst, src reflect.Value) error {
+	for src.Kind() == reflect.Ptr || src.Kind() == reflect.Interface {
+		if src.IsNil() {
+			return nil
+		}
+		src = src.Elem()
+	}
+
+	if dst.Type() == rawMessageType {
+		data, err := json.Marshal(src.Interface())
+		if err != nil {
+			return err
+		}
+		dst.SetBytes(data)
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Ptr:
+		v := reflect.New(dst.Type().Elem())
+		if err := fillPayload(v.Elem(), src); err != nil {
+			return err
+		}
+		dst.Set(v)
+		return nil
+	case reflect.Struct:
+		fields, err := payloadFields(src)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < dst.NumField(); i++ {
+			field, ok := fields[jsonName(dst.Type().Field(i))]
+			if !ok {
+				continue
+			}
+			if err := fillPayload(dst.Field(i), field); err != nil {
+				return fmt.Errorf("%s: %v", jsonName(dst.Type().Field(i)), err)
+			}
+		}
+		return nil
+	case reflect.Slice:
+		if src.Kind() != reflect.Slice && src.Kind() != reflect.Array {
+			return fmt.Errorf("cannot use %s as %s", src.Type(), dst.Type())
+		}
+		slice := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			if err := fillPayload(slice.Index(i), src.Index(i)); err != nil {
+				return err
+			}
+		}
+		dst.Set(slice)
+		return nil
+	case reflect.String:
+		// Timestamps, such as github.Timestamp, are compared as text
+		if src.Kind() != reflect.String && src.Type().Implements(textMarshalerType) {
+			text, err := src.Interface().(encoding.TextMarshaler).MarshalText()
+			if err != nil {
+				return err
+			}
+			dst.SetString(string(text))
+			return nil
+		}
+	}
+
+	if !src.Type().ConvertibleTo(dst.Type()) || (src.Kind() == reflect.String) != (dst.Kind() == reflect.String) {
+		return fmt.Errorf("cannot use %s as %s", src.Type(), dst.Type())
+	}
+	dst.Set(src.Convert(dst.Type()))
+	return nil
+}
+
+// payloadFields returns the fields of src, a struct or map with string keys,
+// keyed by their json name. Fields of embedded structs are promoted.
+func payloadFields(src reflect.Value) (map[string]reflect.Value, error) {
+	fields := make(map[string]reflect.Value)
+	switch {
+	case src.Kind() == reflect.Map && src.Type().Key().Kind() == reflect.String:
+		for _, key := range src.MapKeys() {
+			fields[key.String()] = src.MapIndex(key)
+		}
+	case src.Kind() == reflect.Struct:
+		for i := 0; i < src.NumField(); i++ {
+			field := src.Type().Field(i)
+			if field.Anonymous && field.Tag.Get("json") == "" {
+				embedded := src.Field(i)
+				for embedded.Kind() == reflect.Ptr && !embedded.IsNil() {
+					embedded = embedded.Elem()
+				}
+				if embedded.Kind() != reflect.Struct {
+					continue
+				}
+				promoted, err := payloadFields(embedded)
+				if err != nil {
+					return nil, err
+				}
+				for name, value := range promoted {
+					if _, ok := fields[name]; !ok {
+						fields[name] = value
+					}
+				}
+				continue
+			}
+			if field.PkgPath != "" {
+				continue // unexported
+			}
+			if name := jsonName(field); name != "-" {
+				fields[name] = src.Field(i)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("cannot use %s as object", src.Type())
+	}
+	return fields, nil
+}
+
+// jsonName returns the name of field when encoded as JSON.
+func jsonName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
@@ -0,0 +1,293 @@
+package ghfilter
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+func TestCondition_multiplePayloadFields(t *testing.T) {
+	var (
+		// Labels in the form of objects can not be compared, but must not
+		// prevent other payload fields from being compared.
+		labelObjects = json.RawMessage(`{"action":"opened","issue":{"title":"Title","labels":[{"name":"bug"}],"milestone":{"title":"v1"}}}`)
+		invalid      = json.RawMessage(`{"action":`)
+	)
+
+	tests := []struct {
+		condition Condition
+		payload   *json.RawMessage
+		want      bool
+	}{
+		{Condition{PayloadAction: "opened", PayloadIssueMilestoneTitle: "v1", PayloadIssueTitleRegexp: "^Title$"}, &labelObjects, true},
+		{Condition{PayloadAction: "opened", PayloadIssueLabel: "bug"}, &labelObjects, false},
+		{Condition{PayloadAction: "opened", PayloadIssueLabel: "bug", Negate: true}, &labelObjects, false},
+		{Condition{PayloadAction: "closed", Negate: true}, &labelObjects, true},
+		{Condition{PayloadAction: "opened"}, &invalid, false},
+		{Condition{PayloadAction: "opened", Negate: true}, &invalid, false},
+	}
+
+	for _, test := range tests {
+		have := test.condition.Matches(&github.Event{RawPayload: test.payload})
+		if have != test.want {
+			t.Errorf("condition %+v have: %v, want: %v", test.condition, have, test.want)
+		}
+	}
+}
+
+func TestCondition_invalidPayloadField(t *testing.T) {
+	var (
+		// A field of an unexpected type must only prevent tests using that
+		// field from passing.
+		number = json.RawMessage(`{"action":"opened","number":"x","issue":{"title":"Title"}}`)
+		size   = json.RawMessage(`{"action":"opened","repository":{"size":"large","topics":["go"]}}`)
+		title  = json.RawMessage(`{"action":"opened","issue":{"title":1}}`)
+	)
+
+	tests := []struct {
+		condition Condition
+		payload   *json.RawMessage
+		want      bool
+		wantErr   bool
+	}{
+		{Condition{PayloadAction: "opened"}, &number, true, false},
+		{Condition{PayloadAction: "opened", PayloadIssueTitleRegexp: "^Title$"}, &number, true, false},
+		{Condition{PayloadAction: "opened"}, &size, true, false},
+		{Condition{PayloadRepositorySizeMin: 1}, &size, false, true},
+		{Condition{PayloadRepositoryTopicsAny: []string{"go"}}, &size, false, true},
+		{Condition{PayloadAction: "opened"}, &title, true, false},
+		{Condition{PayloadIssueTitleRegexp: "^Title$"}, &title, false, true},
+		{Condition{PayloadIssueTitleRegexp: "^Title$", Negate: true}, &title, false, true},
+	}
+
+	for _, test := range tests {
+		have, err := test.condition.Test(&github.Event{RawPayload: test.payload})
+		if have != test.want {
+			t.Errorf("condition %+v have: %v, want: %v", test.condition, have, test.want)
+		}
+		if (err != nil) != test.wantErr {
+			t.Errorf("condition %+v have error: %v, want error: %v", test.condition, err, test.wantErr)
+		}
+	}
+
+	event := &github.Event{}
+	payload := map[string]interface{}{"action": "opened", "number": "x"}
+	if !(&Condition{PayloadAction: "opened"}).MatchesWithPayload(event, payload) {
+		t.Errorf("expected payload with unrelated mismatched type to match")
+	}
+	if (&Condition{PayloadIssueNumber: 1}).MatchesWithPayload(event, map[string]interface{}{"issue": map[string]interface{}{"number": "x"}}) {
+		t.Errorf("expected payload with mismatched type to not match")
+	}
+}
+
+func TestCondition_matchesWithPayload(t *testing.T) {
+	issuesEvent := &github.IssuesEvent{
+		Action: github.String("opened"),
+		Issue: &github.Issue{
+			Number:    github.Int(5),
+			Title:     github.String("Crash on start"),
+			Body:      github.String("cc @bradleyfalzon"),
+			Locked:    github.Bool(true),
+			Labels:    []github.Label{{Name: github.String("bug")}},
+			Milestone: &github.Milestone{Title: github.String("v1")},
+		},
+		Repo:   &github.Repository{Topics: []string{"go"}, StargazersCount: github.Int(10)},
+		Sender: &github.User{ID: github.Int(1), Login: github.String("bradleyfalzon")},
+	}
+	raw, err := json.Marshal(issuesEvent)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rawPayload := json.RawMessage(raw)
+	event := &github.Event{Type: github.String("IssuesEvent"), RawPayload: &rawPayload}
+
+	conditions := []Condition{
+		{PayloadAction: "opened"},
+		{PayloadAction: "closed"},
+		{PayloadAction: "closed", Negate: true},
+		{PayloadIssueMilestoneTitle: "v1"},
+		{PayloadIssueTitleRegexp: "^Crash"},
+		{PayloadIssueBodyRegexp: "^nope"},
+		{PayloadIssueAuthorAssociations: []string{"FIRST_TIME_CONTRIBUTOR"}},
+		{PayloadIssueLabel: "bug"},
+		{PayloadRepositoryTopicsAny: []string{"go"}},
+		{PayloadRepositoryStarsMin: 5},
+		{PayloadRepositoryStarsMin: 50},
+		{PayloadSenderID: 1},
+		{PayloadBodyMentions: "bradleyfalzon"},
+		{CompareIssueLocked: true, IssueLocked: true},
+	}
+
+	for _, condition := range conditions {
+		want := condition.Matches(event)
+		for _, payload := range []interface{}{issuesEvent, *issuesEvent} {
+			have := condition.MatchesWithPayload(&github.Event{Type: event.Type}, payload)
+			if have != want {
+				t.Errorf("condition %v payload %T have: %v, want: %v", condition, payload, have, want)
+			}
+		}
+	}
+
+	// Payloads decoded into a map are also supported
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, condition := range conditions {
+		want := condition.Matches(event)
+		have := condition.MatchesWithPayload(event, decoded)
+		if have != want {
+			t.Errorf("condition %v map payload have: %v, want: %v", condition, have, want)
+		}
+	}
+
+	if (&Condition{PayloadAction: "opened", Negate: true}).MatchesWithPayload(event, nil) {
+		t.Errorf("expected nil payload to not match")
+	}
+	if (&Condition{PayloadAction: "opened"}).MatchesWithPayload(event, map[string]interface{}{"action": 1}) {
+		t.Errorf("expected payload with mismatched type to not match")
+	}
+}
+
+func TestLazyPayload_decodesOnce(t *testing.T) {
+	raw := json.RawMessage(`{"action":"opened"}`)
+	p := lazyPayload{event: &github.Event{RawPayload: &raw}}
+	if payload, ok := p.get(); !ok || payload.Action != "opened" {
+		t.Fatalf("unexpected payload: %+v, %v", payload, ok)
+	}
+	// Subsequent calls must reuse the decoded payload
+	raw = json.RawMessage(`{"action":"closed"}`)
+	if payload, ok := p.get(); !ok || payload.Action != "opened" {
+		t.Errorf("payload decoded more than once: %+v, %v", payload, ok)
+	}
+}
+
+func BenchmarkCondition_payload(b *testing.B) {
+	payload := json.RawMessage(`{"action":"opened","issue":{"title":"This will Match","body":"Body","milestone":{"title":"v1"},"labels":["bug"]},"repository":{"topics":["go"]}}`)
+	event := &github.Event{RawPayload: &payload}
+	condition := Condition{
+		PayloadAction:              "opened",
+		PayloadIssueLabel:          "bug",
+		PayloadIssueMilestoneTitle: "v1",
+		PayloadRepositoryTopicsAny: []string{"go"},
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if !condition.Matches(event) {
+			b.Fatal("expected condition to match")
+		}
+	}
+}
+
+func BenchmarkCondition_pullRequestPayload(b *testing.B) {
+	payload := json.RawMessage(`{"action":"opened","number":1,"pull_request":{"number":1,"title":"Add feature","body":"` + strings.Repeat("Lorem ipsum dolor sit amet. ", 200) + `","commits":3,"milestone":{"title":"v1"},"head":{"ref":"feature","repo":{"full_name":"fork/repo"}},"base":{"ref":"master","repo":{"full_name":"owner/repo"}}},"repository":{"full_name":"owner/repo","topics":["go"],"archived":false,"stargazers_count":100},"sender":{"id":1,"login":"octocat"}}`)
+	event := &github.Event{Type: github.String("PullRequestEvent"), RawPayload: &payload}
+
+	conditions := map[string]Condition{
+		"one": {PayloadAction: "opened"},
+		"many": {
+			PayloadAction:                    "opened",
+			PayloadPullRequestMilestoneTitle: "v1",
+			PayloadPullRequestIsCrossRepo:    true,
+			PayloadPullRequestCommitsMin:     1,
+			PayloadRepositoryTopicsAny:       []string{"go"},
+			PayloadRepositoryStarsMin:        10,
+			PayloadSenderID:                  1,
+		},
+	}
+
+	for name, condition := range conditions {
+		condition := condition
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if !condition.Matches(event) {
+					b.Fatal("expected condition to match")
+				}
+			}
+		})
+	}
+
+	// Baseline of the "many" condition decoding the payload separately for
+	// each field, as Matches did before the payload was decoded once.
+	b.Run("many-per-field", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if !matchesPerField(payload) {
+				b.Fatal("expected condition to match")
+			}
+		}
+	})
+}
+
+// matchesPerField matches the "many" condition of
+// BenchmarkCondition_pullRequestPayload, unmarshalling the payload into a
+// separate struct for each field.
+func matchesPerField(raw json.RawMessage) bool {
+	var action struct {
+		Action string `json:"action"`
+	}
+	if json.Unmarshal(raw, &action) != nil || !strings.EqualFold(action.Action, "opened") {
+		return false
+	}
+	var milestone struct {
+		PullRequest struct {
+			Milestone struct {
+				Title string `json:"title"`
+			} `json:"milestone"`
+		} `json:"pull_request"`
+	}
+	if json.Unmarshal(raw, &milestone) != nil || !strings.EqualFold(milestone.PullRequest.Milestone.Title, "v1") {
+		return false
+	}
+	var crossRepo struct {
+		PullRequest struct {
+			Head struct {
+				Repo struct {
+					FullName string `json:"full_name"`
+				} `json:"repo"`
+			} `json:"head"`
+			Base struct {
+				Repo struct {
+					FullName string `json:"full_name"`
+				} `json:"repo"`
+			} `json:"base"`
+		} `json:"pull_request"`
+	}
+	if json.Unmarshal(raw, &crossRepo) != nil || crossRepo.PullRequest.Head.Repo.FullName == crossRepo.PullRequest.Base.Repo.FullName {
+		return false
+	}
+	var commits struct {
+		PullRequest struct {
+			Commits int `json:"commits"`
+		} `json:"pull_request"`
+	}
+	if json.Unmarshal(raw, &commits) != nil || commits.PullRequest.Commits < 1 {
+		return false
+	}
+	var topics struct {
+		Repository struct {
+			Topics []string `json:"topics"`
+		} `json:"repository"`
+	}
+	if json.Unmarshal(raw, &topics) != nil || !containsFold(topics.Repository.Topics, "go") {
+		return false
+	}
+	var stars struct {
+		Repository struct {
+			StargazersCount int `json:"stargazers_count"`
+		} `json:"repository"`
+	}
+	if json.Unmarshal(raw, &stars) != nil || stars.Repository.StargazersCount < 10 {
+		return false
+	}
+	var sender struct {
+		Sender struct {
+			ID int `json:"id"`
+		} `json:"sender"`
+	}
+	return json.Unmarshal(raw, &sender) == nil && sender.Sender.ID == 1
+}
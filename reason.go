@@ -0,0 +1,112 @@
+package ghfilter
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/google/go-github/github"
+)
+
+// ReasonSchemaVersion is the version of the JSON encoding returned by
+// MatchesReasonJSON. It's incremented when a field is removed or its meaning
+// changes, but not when a field is added.
+const ReasonSchemaVersion = 1
+
+// MatchReason describes why a filter did or did not match an event, for
+// rendering by a user interface, see MatchesReasonJSON.
+type MatchReason struct {
+	// Version is ReasonSchemaVersion.
+	Version int `json:"version"`
+	// Matched is the result of the filter's Matches.
+	Matched bool `json:"matched"`
+	// Excluded is true if the event's type is any of the filter's ExcludeTypes,
+	// in which case the event did not match regardless of its conditions.
+	Excluded bool `json:"excluded"`
+	// Logic is the operator combining Conditions, "and" or "or".
+	Logic Logic `json:"logic"`
+	// Conditions is the result of each of the filter's Conditions, in order.
+	Conditions []ConditionReason `json:"conditions"`
+	// Root is the result of the filter's Root, if not nil.
+	Root *RootReason `json:"root,omitempty"`
+}
+
+// ConditionReason is the result of a single condition, see MatchReason.
+type ConditionReason struct {
+	// Index is the condition's index in the filter's Conditions.
+	Index int `json:"index"`
+	// Passed is true if the condition matched the event.
+	Passed bool `json:"passed"`
+	// Reason is the result and description of the condition, such as
+	// `FAIL: payload action is "opened" (got payload action "closed")`, see
+	// ReasonsAll.
+	Reason string `json:"reason"`
+	// Fields are the names of the condition's set fields, see Condition.Fields.
+	Fields []string `json:"fields"`
+}
+
+// RootReason is the result of a filter's Root, see MatchReason.
+type RootReason struct {
+	// Passed is true if Root matched the event.
+	Passed bool `json:"passed"`
+	// Reason is the result and description of Root, such as
+	// `PASS: (type is "IssuesEvent") OR (type is "PushEvent")`.
+	Reason string `json:"reason"`
+}
+
+// MatchReason returns why the filter did or did not match event. Unlike
+// Matches, every condition is evaluated. Funcs are not described, but are
+// included in Matched.
+func (f *Filter) MatchReason(event *github.Event) MatchReason {
+	reason := MatchReason{
+		Version:    ReasonSchemaVersion,
+		Matched:    f.Matches(event),
+		Excluded:   f.excludes(event),
+		Logic:      f.Logic,
+		Conditions: make([]ConditionReason, len(f.Conditions)),
+	}
+	for i, result := range f.Explain(event) {
+		fields := f.Conditions[i].Fields()
+		if fields == nil {
+			fields = []string{} // encode as [] rather than null
+		}
+		reason.Conditions[i] = ConditionReason{
+			Index:  result.Index,
+			Passed: result.Passed,
+			Reason: conditionReason(&f.Conditions[i], event, result.Passed),
+			Fields: fields,
+		}
+	}
+	if f.Root != nil {
+		passed := f.Root.Matches(event)
+		reason.Root = &RootReason{Passed: passed, Reason: resultReason(passed, f.Root.description())}
+	}
+	return reason
+}
+
+// MatchesReasonJSON returns the JSON encoding of MatchReason, such as:
+//
+//	{"version":1,"matched":false,"excluded":false,"logic":"and","conditions":[
+//		{"index":0,"passed":false,"reason":"FAIL: type is \"IssuesEvent\"","fields":["Type"]}
+//	]}
+//
+// The schema is versioned by its version field, see ReasonSchemaVersion.
+func (f *Filter) MatchesReasonJSON(event *github.Event) ([]byte, error) {
+	return json.Marshal(f.MatchReason(event))
+}
+
+// Fields returns the names of the condition's non-zero exported fields, in
+// the order they're declared, such as ["Negate", "Type", "PayloadAction"].
+func (c Condition) Fields() []string {
+	var (
+		fields []string
+		v      = reflect.ValueOf(c)
+		t      = v.Type()
+	)
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).PkgPath != "" || v.Field(i).IsZero() {
+			continue
+		}
+		fields = append(fields, t.Field(i).Name)
+	}
+	return fields
+}
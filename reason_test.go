@@ -0,0 +1,84 @@
+package ghfilter
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+func TestFilter_matchesReasonJSON(t *testing.T) {
+	filter := Filter{
+		Conditions: []Condition{
+			{Type: "IssuesEvent"},
+			{PayloadAction: "opened", PayloadIssueLabel: "bug"},
+			{RepositoryID: 1, Negate: true},
+			{},
+		},
+		Root:         NewOr(&Condition{PayloadIssueState: "open"}, &Condition{PayloadIssueNumber: 42}),
+		ExcludeTypes: []string{"WatchEvent"},
+	}
+
+	payload := json.RawMessage(`{"action":"opened","issue":{"number":42,"state":"closed","labels":["enhancement"]}}`)
+	event := &github.Event{
+		Type:       github.String("IssuesEvent"),
+		Repo:       &github.Repository{ID: github.Int(2)},
+		RawPayload: &payload,
+	}
+
+	data, err := filter.MatchesReasonJSON(event)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var have bytes.Buffer
+	if err := json.Indent(&have, data, "", "\t"); err != nil {
+		t.Fatalf("unexpected error indenting %s: %v", data, err)
+	}
+	have.WriteByte('\n')
+
+	golden := filepath.Join("testdata", "matches_reason.golden.json")
+	if *update {
+		if err := ioutil.WriteFile(golden, have.Bytes(), 0644); err != nil {
+			t.Fatalf("could not update golden file: %v", err)
+		}
+	}
+	want, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("could not read golden file: %v", err)
+	}
+	if !bytes.Equal(have.Bytes(), want) {
+		t.Errorf("JSON does not match %s, run go test -update to update\nhave: %s\nwant: %s", golden, have.Bytes(), want)
+	}
+
+	var decoded MatchReason
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error decoding %s: %v", data, err)
+	}
+	if !reflect.DeepEqual(decoded, filter.MatchReason(event)) {
+		t.Errorf("decoded reason does not match\nhave: %+v\nwant: %+v", decoded, filter.MatchReason(event))
+	}
+}
+
+func TestCondition_fields(t *testing.T) {
+	tests := []struct {
+		condition Condition
+		want      []string
+	}{
+		{Condition{}, nil},
+		{Condition{Type: "IssuesEvent", PayloadAction: "opened", Negate: true}, []string{"Negate", "Type", "PayloadAction"}},
+		{Condition{ActorAllowed: func(string) bool { return true }, MaxAge: 1}, []string{"MaxAge", "ActorAllowed"}},
+	}
+
+	for _, test := range tests {
+		if have := test.condition.Fields(); !reflect.DeepEqual(have, test.want) {
+			t.Errorf("condition %v have: %q, want: %q", test.condition, have, test.want)
+		}
+	}
+}
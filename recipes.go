@@ -0,0 +1,93 @@
+package ghfilter
+
+// FirstTimeContributorAssociations are the issue author associations used by
+// FirstTimeContributorIssues to approximate a first time contributor to a
+// repository.
+var FirstTimeContributorAssociations = []string{"FIRST_TIME_CONTRIBUTOR", "FIRST_TIMER"}
+
+// FirstTimeContributorIssues returns a Filter matching issues opened by an
+// author whose association with the repository is any of
+// FirstTimeContributorAssociations.
+func FirstTimeContributorIssues() *Filter {
+	associations := make([]string, len(FirstTimeContributorAssociations))
+	copy(associations, FirstTimeContributorAssociations)
+	return &Filter{
+		Conditions: []Condition{
+			{
+				Type:                           "IssuesEvent",
+				PayloadAction:                  "opened",
+				PayloadIssueAuthorAssociations: associations,
+			},
+		},
+	}
+}
+
+// VisibilityChanges returns a Filter matching repositories being made public or
+// private.
+func VisibilityChanges() *Filter {
+	return &Filter{
+		Conditions: []Condition{
+			{
+				Type:             "RepositoryEvent",
+				PayloadActionAny: []string{"publicized", "privatized"},
+			},
+		},
+	}
+}
+
+// LockedItems returns a Filter matching issues and pull requests being locked.
+// Issues are locked in an IssuesEvent and pull requests in a PullRequestEvent,
+// each with the action "locked".
+func LockedItems() *Filter {
+	return &Filter{
+		Logic: Or,
+		Conditions: []Condition{
+			{
+				Type:               "IssuesEvent",
+				PayloadAction:      "locked",
+				CompareIssueLocked: true,
+				IssueLocked:        true,
+			},
+			{
+				Type:          "PullRequestEvent",
+				PayloadAction: "locked",
+			},
+		},
+	}
+}
+
+// ReviewRequestRemoved returns a Filter matching pull requests having a review
+// request removed. The filter has a single condition, which can be extended,
+// such as to only match a reviewer or to also match review requests being
+// added:
+//
+//	filter := ReviewRequestRemoved()
+//	filter.Conditions[0].PayloadPullRequestRequestedReviewer = "octocat"
+//	filter.Conditions[0].PayloadActionAny = append(filter.Conditions[0].PayloadActionAny, "review_requested")
+//
+// Conditions appended to the filter's Conditions must also match, so cannot be
+// used to match additional actions.
+func ReviewRequestRemoved() *Filter {
+	return &Filter{
+		Conditions: []Condition{
+			{
+				Type:             "PullRequestEvent",
+				PayloadActionAny: []string{"review_request_removed"},
+			},
+		},
+	}
+}
+
+// ResolvedReviewThreads returns a Filter matching pull request review comment
+// threads being marked as resolved. Threads being marked as unresolved, with the
+// action "unresolved", are not matched.
+func ResolvedReviewThreads() *Filter {
+	return &Filter{
+		Conditions: []Condition{
+			{
+				Type:          "PullRequestReviewThreadEvent",
+				PayloadAction: "resolved",
+			},
+		},
+	}
+}
@@ -0,0 +1,227 @@
+package ghfilter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+func TestFirstTimeContributorIssues(t *testing.T) {
+	var (
+		firstTimer = json.RawMessage(`{"action":"opened","issue":{"author_association":"FIRST_TIMER"}}`)
+		member     = json.RawMessage(`{"action":"opened","issue":{"author_association":"MEMBER"}}`)
+		closed     = json.RawMessage(`{"action":"closed","issue":{"author_association":"FIRST_TIME_CONTRIBUTOR"}}`)
+	)
+
+	tests := []struct {
+		event *github.Event
+		want  bool
+	}{
+		{
+			event: &github.Event{Type: github.String("IssuesEvent"), RawPayload: &firstTimer},
+			want:  true,
+		},
+		{
+			event: &github.Event{Type: github.String("IssuesEvent"), RawPayload: &member},
+			want:  false,
+		},
+		{
+			event: &github.Event{Type: github.String("IssuesEvent"), RawPayload: &closed},
+			want:  false,
+		},
+	}
+
+	filter := FirstTimeContributorIssues()
+	for _, test := range tests {
+		have := filter.Matches(test.event)
+		if have != test.want {
+			t.Errorf("have: %v, want %v", have, test.want)
+		}
+	}
+}
+
+func TestVisibilityChanges(t *testing.T) {
+	var (
+		publicized = json.RawMessage(`{"action":"publicized","repository":{"full_name":"owner/repo","private":false}}`)
+		privatized = json.RawMessage(`{"action":"privatized","repository":{"full_name":"owner/repo","private":true}}`)
+		created    = json.RawMessage(`{"action":"created","repository":{"full_name":"owner/repo","private":false}}`)
+	)
+
+	tests := []struct {
+		event *github.Event
+		want  bool
+	}{
+		{
+			event: &github.Event{Type: github.String("RepositoryEvent"), RawPayload: &publicized},
+			want:  true,
+		},
+		{
+			event: &github.Event{Type: github.String("RepositoryEvent"), RawPayload: &privatized},
+			want:  true,
+		},
+		{
+			event: &github.Event{Type: github.String("RepositoryEvent"), RawPayload: &created},
+			want:  false,
+		},
+		{
+			event: &github.Event{Type: github.String("PublicEvent"), RawPayload: &publicized},
+			want:  false,
+		},
+	}
+
+	filter := VisibilityChanges()
+	for _, test := range tests {
+		have := filter.Matches(test.event)
+		if have != test.want {
+			t.Errorf("have: %v, want %v", have, test.want)
+		}
+	}
+}
+
+func TestLockedItems(t *testing.T) {
+	var (
+		locked     = json.RawMessage(`{"action":"locked","issue":{"number":1,"locked":true,"active_lock_reason":"spam"}}`)
+		unlocked   = json.RawMessage(`{"action":"unlocked","issue":{"number":1,"locked":false}}`)
+		prLocked   = json.RawMessage(`{"action":"locked","number":2,"pull_request":{"number":2,"locked":true,"active_lock_reason":"too heated"}}`)
+		prUnlocked = json.RawMessage(`{"action":"unlocked","number":2,"pull_request":{"number":2,"locked":false}}`)
+	)
+
+	tests := []struct {
+		event *github.Event
+		want  bool
+	}{
+		{
+			event: &github.Event{Type: github.String("IssuesEvent"), RawPayload: &locked},
+			want:  true,
+		},
+		{
+			event: &github.Event{Type: github.String("IssuesEvent"), RawPayload: &unlocked},
+			want:  false,
+		},
+		{
+			event: &github.Event{Type: github.String("PullRequestEvent"), RawPayload: &prLocked},
+			want:  true,
+		},
+		{
+			event: &github.Event{Type: github.String("PullRequestEvent"), RawPayload: &prUnlocked},
+			want:  false,
+		},
+	}
+
+	filter := LockedItems()
+	for _, test := range tests {
+		have := filter.Matches(test.event)
+		if have != test.want {
+			t.Errorf("have: %v, want %v", have, test.want)
+		}
+	}
+}
+
+func TestReviewRequestRemoved(t *testing.T) {
+	var (
+		removed   = json.RawMessage(`{"action":"review_request_removed","number":1,"pull_request":{"number":1},"requested_reviewer":{"login":"octocat"}}`)
+		requested = json.RawMessage(`{"action":"review_requested","number":1,"pull_request":{"number":1},"requested_reviewer":{"login":"octocat"}}`)
+	)
+
+	tests := []struct {
+		event *github.Event
+		want  bool
+	}{
+		{
+			event: &github.Event{Type: github.String("PullRequestEvent"), RawPayload: &removed},
+			want:  true,
+		},
+		{
+			event: &github.Event{Type: github.String("PullRequestEvent"), RawPayload: &requested},
+			want:  false,
+		},
+		{
+			event: &github.Event{Type: github.String("IssuesEvent"), RawPayload: &removed},
+			want:  false,
+		},
+	}
+
+	filter := ReviewRequestRemoved()
+	for _, test := range tests {
+		have := filter.Matches(test.event)
+		if have != test.want {
+			t.Errorf("have: %v, want %v", have, test.want)
+		}
+	}
+
+	other := json.RawMessage(`{"action":"review_request_removed","number":1,"pull_request":{"number":1},"requested_reviewer":{"login":"hubot"}}`)
+	combined := ReviewRequestRemoved()
+	combined.Conditions[0].PayloadPullRequestRequestedReviewer = "OctoCat"
+	combined.Conditions[0].PayloadActionAny = append(combined.Conditions[0].PayloadActionAny, "review_requested")
+
+	combinedTests := []struct {
+		payload *json.RawMessage
+		want    bool
+	}{
+		{&removed, true},
+		{&requested, true},
+		{&other, false},
+	}
+	for _, test := range combinedTests {
+		have := combined.Matches(&github.Event{Type: github.String("PullRequestEvent"), RawPayload: test.payload})
+		if have != test.want {
+			t.Errorf("combined payload %s have: %v, want %v", *test.payload, have, test.want)
+		}
+	}
+}
+
+func TestResolvedReviewThreads(t *testing.T) {
+	var (
+		resolved = json.RawMessage(`{
+			"action": "resolved",
+			"pull_request": {"number": 1, "head": {"ref": "feature"}, "base": {"ref": "main"}},
+			"thread": {
+				"node_id": "PRRT_kwDOA",
+				"comments": [{"id": 1, "body": "Typo here", "path": "README.md", "user": {"login": "octocat"}}]
+			},
+			"repository": {"full_name": "owner/repo"},
+			"sender": {"login": "octocat"}
+		}`)
+		unresolved = json.RawMessage(`{
+			"action": "unresolved",
+			"pull_request": {"number": 1, "head": {"ref": "feature"}, "base": {"ref": "main"}},
+			"thread": {
+				"node_id": "PRRT_kwDOA",
+				"comments": [{"id": 1, "body": "Typo here", "path": "README.md", "user": {"login": "octocat"}}]
+			},
+			"repository": {"full_name": "owner/repo"},
+			"sender": {"login": "octocat"}
+		}`)
+	)
+
+	tests := []struct {
+		event *github.Event
+		want  bool
+	}{
+		{
+			event: &github.Event{Type: github.String("PullRequestReviewThreadEvent"), RawPayload: &resolved},
+			want:  true,
+		},
+		{
+			event: &github.Event{Type: github.String("PullRequestReviewThreadEvent"), RawPayload: &unresolved},
+			want:  false,
+		},
+		{
+			event: &github.Event{Type: github.String("PullRequestReviewThreadEvent"), RawPayload: nil},
+			want:  false,
+		},
+		{
+			event: &github.Event{Type: github.String("PullRequestReviewCommentEvent"), RawPayload: &resolved},
+			want:  false,
+		},
+	}
+
+	filter := ResolvedReviewThreads()
+	for _, test := range tests {
+		have := filter.Matches(test.event)
+		if have != test.want {
+			t.Errorf("have: %v, want %v", have, test.want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package ghfilter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Resolve returns a copy of the filter with all Includes, and their Includes,
+// replaced by the conditions, roots, funcs and excluded types of the named
+// filters in filters, roots are combined using And. An error is returned if an
+// included filter does not exist, a filter includes itself, directly or
+// indirectly, or a filter using Or logic has includes or is included, as its
+// conditions cannot be combined with another filter's.
+func (f *Filter) Resolve(filters map[string]*Filter) (*Filter, error) {
+	resolved := &Filter{StrictRegexp: f.StrictRegexp, Logic: f.Logic}
+	if err := resolved.include(f, filters, nil); err != nil {
+		return nil, err
+	}
+	return resolved, nil
+}
+
+// include appends the conditions and funcs of filter, and the filters it
+// includes, to f. Path contains the names of filters currently being included
+// and is used to detect cycles.
+func (f *Filter) include(filter *Filter, filters map[string]*Filter, path []string) error {
+	for _, condition := range filter.Conditions {
+		f.Conditions = append(f.Conditions, condition.clone())
+	}
+	if filter.Root != nil {
+		if f.Root == nil {
+			f.Root = filter.Root.clone()
+		} else {
+			f.Root = NewAnd(f.Root, filter.Root.clone())
+		}
+	}
+	f.Funcs = append(f.Funcs, filter.Funcs...)
+	f.ExcludeTypes = append(f.ExcludeTypes, filter.ExcludeTypes...)
+
+	if filter.Logic == Or && len(filter.Includes) > 0 {
+		return fmt.Errorf("filter with or logic cannot have includes")
+	}
+
+	for _, name := range filter.Includes {
+		for _, seen := range path {
+			if seen == name {
+				return fmt.Errorf("filter %q includes itself: %s", name, strings.Join(append(path, name), " -> "))
+			}
+		}
+		included, ok := filters[name]
+		if !ok {
+			return fmt.Errorf("included filter %q not found", name)
+		}
+		if included.Logic == Or {
+			return fmt.Errorf("included filter %q uses or logic", name)
+		}
+		if err := f.include(included, filters, append(path[:len(path):len(path)], name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
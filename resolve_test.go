@@ -0,0 +1,63 @@
+package ghfilter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFilter_resolve(t *testing.T) {
+	filters := map[string]*Filter{
+		"issues": {
+			Conditions: []Condition{{Type: "IssuesEvent"}},
+		},
+		"opened-issues": {
+			Conditions: []Condition{{PayloadAction: "opened"}},
+			Includes:   []string{"issues"},
+		},
+	}
+
+	filter := &Filter{
+		Conditions: []Condition{{RepositoryID: 1}},
+		Includes:   []string{"opened-issues"},
+	}
+
+	have, err := filter.Resolve(filters)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []Condition{
+		{RepositoryID: 1},
+		{PayloadAction: "opened"},
+		{Type: "IssuesEvent"},
+	}
+	if !reflect.DeepEqual(have.Conditions, want) {
+		t.Errorf("resolved conditions do not match\nhave: %+v\nwant: %+v", have.Conditions, want)
+	}
+	if have.Includes != nil {
+		t.Errorf("expected resolved filter to have no includes, have: %v", have.Includes)
+	}
+}
+
+func TestFilter_resolveErrors(t *testing.T) {
+	filters := map[string]*Filter{
+		"a":    {Includes: []string{"b"}},
+		"b":    {Includes: []string{"a"}},
+		"self": {Includes: []string{"self"}},
+		"or":   {Logic: Or, Conditions: []Condition{{Type: "IssuesEvent"}}},
+	}
+
+	tests := []*Filter{
+		{Includes: []string{"a"}},
+		{Includes: []string{"self"}},
+		{Includes: []string{"missing"}},
+		{Includes: []string{"or"}},
+		{Logic: Or, Includes: []string{"a"}},
+	}
+
+	for _, filter := range tests {
+		if _, err := filter.Resolve(filters); err == nil {
+			t.Errorf("expected error resolving %v", filter.Includes)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package ghfilter
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/go-github/github"
+)
+
+// Ruleset values for Match.
+const (
+	MatchAll = "all"
+	MatchAny = "any"
+)
+
+// Ruleset is a list of conditions and nested rulesets combined by Match, a
+// common shape for rules in configuration files, such as:
+//
+//	{"match": "any", "conditions": [...], "rulesets": [{"match": "all", ...}]}
+type Ruleset struct {
+	// Match is MatchAll, requiring all conditions and rulesets to match, or
+	// MatchAny, requiring any condition or ruleset to match. An empty Match is
+	// MatchAll.
+	Match      string      `json:"match,omitempty"`
+	Conditions []Condition `json:"conditions,omitempty"`
+	Rulesets   []Ruleset   `json:"rulesets,omitempty"`
+}
+
+// Matches returns true if the ruleset's conditions and rulesets, combined using
+// Match, match event. Conditions are evaluated before rulesets, in order, until
+// the result is known. A ruleset without conditions or rulesets matches if
+// Match is MatchAll and does not match if Match is MatchAny. A ruleset with an
+// unknown Match never matches.
+func (r *Ruleset) Matches(event *github.Event) bool {
+	logic, err := r.logic()
+	if err != nil {
+		return false
+	}
+	if logic == Or {
+		for i := range r.Conditions {
+			if r.Conditions[i].Matches(event) {
+				return true
+			}
+		}
+		for i := range r.Rulesets {
+			if r.Rulesets[i].Matches(event) {
+				return true
+			}
+		}
+		return false
+	}
+	for i := range r.Conditions {
+		if !r.Conditions[i].Matches(event) {
+			return false
+		}
+	}
+	for i := range r.Rulesets {
+		if !r.Rulesets[i].Matches(event) {
+			return false
+		}
+	}
+	return true
+}
+
+// logic returns the Logic for Match, or an error if Match is unknown.
+func (r *Ruleset) logic() (Logic, error) {
+	switch r.Match {
+	case MatchAll, "":
+		return And, nil
+	case MatchAny:
+		return Or, nil
+	}
+	return And, fmt.Errorf("unknown match %q, must be %q or %q", r.Match, MatchAll, MatchAny)
+}
+
+// jsonRuleset has the same fields as Ruleset but without its methods.
+type jsonRuleset Ruleset
+
+// UnmarshalJSON implements the json.Unmarshaler interface, returning an error
+// if the ruleset, or a nested ruleset, has an unknown match.
+func (r *Ruleset) UnmarshalJSON(data []byte) error {
+	var ruleset jsonRuleset
+	if err := json.Unmarshal(data, &ruleset); err != nil {
+		return err
+	}
+	if _, err := (*Ruleset)(&ruleset).logic(); err != nil {
+		return err
+	}
+	*r = Ruleset(ruleset)
+	return nil
+}
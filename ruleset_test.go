@@ -0,0 +1,85 @@
+package ghfilter
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+func TestRuleset_matches(t *testing.T) {
+	var ruleset Ruleset
+	err := json.Unmarshal([]byte(`{
+		"match": "any",
+		"rulesets": [
+			{
+				"match": "all",
+				"conditions": [{"Type": "IssuesEvent"}, {"ComparePublic": true, "Public": true}]
+			},
+			{
+				"conditions": [{"Type": "PushEvent"}],
+				"rulesets": [
+					{"match": "any", "conditions": [{"RepositoryID": 1}, {"RepositoryID": 2}]}
+				]
+			}
+		]
+	}`), &ruleset)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		event *github.Event
+		want  bool
+	}{
+		{event: &github.Event{Type: github.String("IssuesEvent"), Public: github.Bool(true)}, want: true},
+		{event: &github.Event{Type: github.String("IssuesEvent"), Public: github.Bool(false)}, want: false},
+		{event: &github.Event{Type: github.String("PushEvent"), Repo: &github.Repository{ID: github.Int(2)}}, want: true},
+		{event: &github.Event{Type: github.String("PushEvent"), Repo: &github.Repository{ID: github.Int(3)}}, want: false},
+		{event: &github.Event{Type: github.String("ForkEvent"), Public: github.Bool(true)}, want: false},
+	}
+
+	for _, test := range tests {
+		if have := ruleset.Matches(test.event); have != test.want {
+			t.Errorf("event %v have: %v, want: %v", *test.event.Type, have, test.want)
+		}
+	}
+
+	data, err := json.Marshal(ruleset)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded Ruleset
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, ruleset) {
+		t.Errorf("decoded ruleset does not match\nhave: %+v\nwant: %+v", decoded, ruleset)
+	}
+}
+
+func TestRuleset_empty(t *testing.T) {
+	event := &github.Event{Type: github.String("IssuesEvent")}
+	tests := []struct {
+		ruleset Ruleset
+		want    bool
+	}{
+		{Ruleset{}, true},
+		{Ruleset{Match: MatchAll}, true},
+		{Ruleset{Match: MatchAny}, false},
+		{Ruleset{Match: "unknown"}, false},
+	}
+	for _, test := range tests {
+		if have := test.ruleset.Matches(event); have != test.want {
+			t.Errorf("match %q have: %v, want: %v", test.ruleset.Match, have, test.want)
+		}
+	}
+}
+
+func TestRuleset_unmarshalUnknownMatch(t *testing.T) {
+	var ruleset Ruleset
+	if err := json.Unmarshal([]byte(`{"rulesets": [{"match": "some"}]}`), &ruleset); err == nil {
+		t.Errorf("expected error for unknown match")
+	}
+}
@@ -0,0 +1,67 @@
+package ghfilter
+
+import (
+	"math"
+	"sync/atomic"
+
+	"github.com/google/go-github/github"
+)
+
+// SamplingFilter wraps a Filter and matches only every Nth event which matches
+// the wrapped filter, such as to sample 1 in 100 events from a high volume
+// stream. A SamplingFilter is safe for concurrent use.
+type SamplingFilter struct {
+	filter *Filter
+	n      uint64
+	count  uint64 // count is the number of events matching filter, accessed atomically
+}
+
+// NewSamplingFilter returns a SamplingFilter which matches every nth event
+// matching filter, starting with the nth. If n is less than 2, every event
+// matching filter matches.
+func NewSamplingFilter(filter *Filter, n int) *SamplingFilter {
+	if n < 1 {
+		n = 1
+	}
+	return &SamplingFilter{filter: filter, n: uint64(n)}
+}
+
+// Matches returns true if event matches the wrapped filter and it's the nth
+// such event since the previous sampled event, else return false.
+func (s *SamplingFilter) Matches(event *github.Event) bool {
+	if !s.filter.Matches(event) {
+		return false
+	}
+	return atomic.AddUint64(&s.count, 1)%s.n == 0
+}
+
+// Reset restarts counting, so the next sampled event is the nth matching event
+// after Reset.
+func (s *SamplingFilter) Reset() {
+	atomic.StoreUint64(&s.count, 0)
+}
+
+// inSample returns true if id is within a deterministic sample of rate, from 0
+// to 1, of all ids, by comparing a hash of id. The same id and rate always
+// return the same result, including across processes, and ids within a sample
+// are also within any larger sample.
+func inSample(id string, rate float64) bool {
+	if rate >= 1 {
+		return true
+	}
+	// 64 bit FNV-1a, inlined to avoid allocating a hash.Hash64
+	hash := uint64(14695981039346656037)
+	for i := 0; i < len(id); i++ {
+		hash ^= uint64(id[i])
+		hash *= 1099511628211
+	}
+	// FNV's high bits change little between ids differing only in their last
+	// characters, such as sequential ids, so mix them using MurmurHash3's
+	// finalizer
+	hash ^= hash >> 33
+	hash *= 0xff51afd7ed558ccd
+	hash ^= hash >> 33
+	hash *= 0xc4ceb9fe1a85ec53
+	hash ^= hash >> 33
+	return float64(hash) < rate*math.MaxUint64
+}
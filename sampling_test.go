@@ -0,0 +1,115 @@
+package ghfilter
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+func TestSamplingFilter(t *testing.T) {
+	var (
+		issues = &github.Event{Type: github.String("IssuesEvent")}
+		push   = &github.Event{Type: github.String("PushEvent")}
+	)
+
+	sample := NewSamplingFilter(&Filter{Conditions: []Condition{{Type: "IssuesEvent"}}}, 3)
+
+	tests := []struct {
+		event *github.Event
+		want  bool
+	}{
+		{issues, false},
+		{push, false}, // does not match filter, not counted
+		{issues, false},
+		{issues, true}, // 3rd match
+		{issues, false},
+		{push, false},
+		{issues, false},
+		{issues, true}, // 6th match
+	}
+
+	for i, test := range tests {
+		if have := sample.Matches(test.event); have != test.want {
+			t.Errorf("test %d: have: %v, want: %v", i, have, test.want)
+		}
+	}
+
+	sample.Matches(issues)
+	sample.Reset()
+	for i, want := range []bool{false, false, true} {
+		if have := sample.Matches(issues); have != want {
+			t.Errorf("after reset %d: have: %v, want: %v", i, have, want)
+		}
+	}
+}
+
+func TestSamplingFilter_concurrent(t *testing.T) {
+	var (
+		sample  = NewSamplingFilter(&Filter{}, 3)
+		event   = &github.Event{Type: github.String("IssuesEvent")}
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		matched int
+	)
+	for i := 0; i < 30; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if sample.Matches(event) {
+				mu.Lock()
+				matched++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if matched != 10 {
+		t.Errorf("have %d sampled events, want 10", matched)
+	}
+}
+
+func TestCondition_payloadSampleRate(t *testing.T) {
+	var (
+		tenth   = Condition{PayloadSampleRate: 0.1}
+		half    = Condition{PayloadSampleRate: 0.5}
+		negated = Condition{PayloadSampleRate: 0.1, Negate: true}
+		sampled int
+	)
+	for i := 0; i < 10000; i++ {
+		event := &github.Event{ID: github.String(strconv.Itoa(5000000000 + i))}
+		in := tenth.Matches(event)
+		if in {
+			sampled++
+		}
+		if again := tenth.Matches(&github.Event{ID: github.String(event.GetID())}); again != in {
+			t.Fatalf("event %s have: %v, then: %v", event.GetID(), in, again)
+		}
+		if negated.Matches(event) == in {
+			t.Errorf("event %s matched both or neither of the sample and its negation", event.GetID())
+		}
+		if in && !half.Matches(event) {
+			t.Errorf("event %s in 10%% sample but not 50%% sample", event.GetID())
+		}
+	}
+	if sampled < 900 || sampled > 1100 {
+		t.Errorf("have %d of 10000 events sampled, want approximately 1000", sampled)
+	}
+
+	// Results must be stable across versions and processes
+	for id, want := range map[string]bool{"5000000006": false, "5000000007": true, "5000000014": true, "5000000015": false} {
+		if have := tenth.Matches(&github.Event{ID: github.String(id)}); have != want {
+			t.Errorf("event %s have: %v, want: %v", id, have, want)
+		}
+	}
+
+	noID := &github.Event{Type: github.String("IssuesEvent")}
+	if tenth.Matches(noID) || negated.Matches(noID) {
+		t.Errorf("expected event without ID not to match")
+	}
+	all := Condition{PayloadSampleRate: 1}
+	if !all.Matches(noID) {
+		t.Errorf("expected rate 1 to match event without ID")
+	}
+}
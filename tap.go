@@ -0,0 +1,23 @@
+package ghfilter
+
+import "github.com/google/go-github/github"
+
+// Tap returns a channel receiving every event read from in, unchanged and in
+// order, calling onEach with each event and whether it matches the filter
+// before the event is sent. This allows a filter's decisions to be inspected,
+// such as logged, without removing events from a stream.
+//
+// The returned channel is closed when in is closed. onEach is called from a
+// single goroutine, and the caller must receive from the returned channel
+// until it is closed to prevent the goroutine from leaking.
+func (f *Filter) Tap(in <-chan *github.Event, onEach func(*github.Event, bool)) <-chan *github.Event {
+	out := make(chan *github.Event)
+	go func() {
+		defer close(out)
+		for event := range in {
+			onEach(event, f.Matches(event))
+			out <- event
+		}
+	}()
+	return out
+}
@@ -0,0 +1,51 @@
+package ghfilter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+func TestFilter_tap(t *testing.T) {
+	filter := Filter{Conditions: []Condition{{Type: "IssuesEvent"}}}
+
+	events := []*github.Event{
+		{Type: github.String("IssuesEvent")},
+		{Type: github.String("PushEvent")},
+		{Type: github.String("IssuesEvent")},
+		{Type: github.String("WatchEvent")},
+	}
+
+	in := make(chan *github.Event)
+	go func() {
+		for _, event := range events {
+			in <- event
+		}
+		close(in)
+	}()
+
+	var (
+		tapped  []*github.Event
+		matched []bool
+	)
+	out := filter.Tap(in, func(event *github.Event, match bool) {
+		tapped = append(tapped, event)
+		matched = append(matched, match)
+	})
+
+	var have []*github.Event
+	for event := range out {
+		have = append(have, event)
+	}
+
+	if !reflect.DeepEqual(have, events) {
+		t.Errorf("forwarded events do not match\nhave: %v\nwant: %v", have, events)
+	}
+	if !reflect.DeepEqual(tapped, events) {
+		t.Errorf("tapped events do not match\nhave: %v\nwant: %v", tapped, events)
+	}
+	if want := []bool{true, false, true, false}; !reflect.DeepEqual(matched, want) {
+		t.Errorf("match results do not match\nhave: %v\nwant: %v", matched, want)
+	}
+}
@@ -0,0 +1,296 @@
+package ghfilter
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MarshalText implements the encoding.TextMarshaler interface, encoding the
+// condition's non-zero fields on a single line as space separated Name=value
+// pairs, such as:
+//
+//	Negate=true Type="IssuesEvent" PayloadActionAny=["opened", "reopened"]
+//
+// Strings and times are quoted using Go syntax, times are formatted as RFC 3339,
+// durations are formatted by time.Duration's String method, such as 1h30m0s,
+// and string slices are a bracketed list of quoted strings. Fields implementing
+// encoding.TextMarshaler, such as BusinessHours, are quoted. Func fields are not
+// encoded.
+func (c Condition) MarshalText() ([]byte, error) {
+	var (
+		pairs []string
+		v     = reflect.ValueOf(c)
+		t     = v.Type()
+	)
+	for i := 0; i < t.NumField(); i++ {
+		field, value := t.Field(i), v.Field(i)
+		if field.PkgPath != "" || value.Kind() == reflect.Func || value.IsZero() {
+			continue
+		}
+		text, err := marshalTextValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %v", field.Name, err)
+		}
+		pairs = append(pairs, field.Name+"="+text)
+	}
+	return []byte(strings.Join(pairs, " ")), nil
+}
+
+// marshalTextValue returns the text encoding of a single condition field.
+func marshalTextValue(value reflect.Value) (string, error) {
+	if t, ok := value.Interface().(time.Time); ok {
+		return strconv.Quote(t.Format(time.RFC3339Nano)), nil
+	}
+	if d, ok := value.Interface().(time.Duration); ok {
+		return d.String(), nil
+	}
+	if m, ok := value.Interface().(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		if err != nil {
+			return "", err
+		}
+		return strconv.Quote(string(text)), nil
+	}
+	switch value.Kind() {
+	case reflect.Ptr:
+		return marshalTextValue(value.Elem())
+	case reflect.String:
+		return strconv.Quote(value.String()), nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	case reflect.Int:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'g', -1, 64), nil
+	case reflect.Slice:
+		if strs, ok := value.Interface().([]string); ok {
+			return quoteList(strs), nil
+		}
+	}
+	return "", fmt.Errorf("unsupported type %s", value.Type())
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, decoding a
+// condition encoded by MarshalText. Fields not present in text are reset to
+// their zero value.
+func (c *Condition) UnmarshalText(text []byte) error {
+	var (
+		condition Condition
+		v         = reflect.ValueOf(&condition).Elem()
+		s         = strings.TrimSpace(string(text))
+	)
+	for s != "" {
+		eq := strings.IndexByte(s, '=')
+		if eq < 1 {
+			return fmt.Errorf("expected Name=value at %q", s)
+		}
+		name := s[:eq]
+		field, ok := v.Type().FieldByName(name)
+		if !ok || field.PkgPath != "" || field.Type.Kind() == reflect.Func {
+			return fmt.Errorf("unknown field %q", name)
+		}
+		rest, err := unmarshalTextValue(v.FieldByIndex(field.Index), s[eq+1:])
+		if err != nil {
+			return fmt.Errorf("field %s: %v", name, err)
+		}
+		if rest != "" && rest[0] != ' ' {
+			return fmt.Errorf("field %s: expected space after value at %q", name, rest)
+		}
+		s = strings.TrimLeft(rest, " ")
+	}
+	*c = condition
+	return nil
+}
+
+// unmarshalTextValue decodes the value at the start of s into value, returning
+// the remainder of s.
+func unmarshalTextValue(value reflect.Value, s string) (string, error) {
+	if _, ok := value.Interface().(time.Time); ok {
+		str, rest, err := unquotePrefix(s)
+		if err != nil {
+			return "", err
+		}
+		t, err := time.Parse(time.RFC3339Nano, str)
+		if err != nil {
+			return "", err
+		}
+		value.Set(reflect.ValueOf(t))
+		return rest, nil
+	}
+	if _, ok := value.Interface().(time.Duration); ok {
+		token, rest := bareToken(s)
+		d, err := time.ParseDuration(token)
+		if err != nil {
+			return "", err
+		}
+		value.Set(reflect.ValueOf(d))
+		return rest, nil
+	}
+	if value.Kind() == reflect.Ptr && value.Type().Implements(textUnmarshalerType) {
+		str, rest, err := unquotePrefix(s)
+		if err != nil {
+			return "", err
+		}
+		v := reflect.New(value.Type().Elem())
+		if err := v.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(str)); err != nil {
+			return "", err
+		}
+		value.Set(v)
+		return rest, nil
+	}
+
+	switch value.Kind() {
+	case reflect.Ptr:
+		v := reflect.New(value.Type().Elem())
+		rest, err := unmarshalTextValue(v.Elem(), s)
+		if err != nil {
+			return "", err
+		}
+		value.Set(v)
+		return rest, nil
+	case reflect.String:
+		str, rest, err := unquotePrefix(s)
+		if err != nil {
+			return "", err
+		}
+		value.SetString(str)
+		return rest, nil
+	case reflect.Bool:
+		token, rest := bareToken(s)
+		b, err := strconv.ParseBool(token)
+		if err != nil {
+			return "", err
+		}
+		value.SetBool(b)
+		return rest, nil
+	case reflect.Int:
+		token, rest := bareToken(s)
+		n, err := strconv.ParseInt(token, 10, 0)
+		if err != nil {
+			return "", err
+		}
+		value.SetInt(n)
+		return rest, nil
+	case reflect.Float64:
+		token, rest := bareToken(s)
+		f, err := strconv.ParseFloat(token, 64)
+		if err != nil {
+			return "", err
+		}
+		value.SetFloat(f)
+		return rest, nil
+	case reflect.Slice:
+		if _, ok := value.Interface().([]string); ok {
+			strs, rest, err := unquoteList(s)
+			if err != nil {
+				return "", err
+			}
+			value.Set(reflect.ValueOf(strs))
+			return rest, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported type %s", value.Type())
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// bareToken returns the text up to the first space in s and the remainder.
+func bareToken(s string) (token, rest string) {
+	if i := strings.IndexByte(s, ' '); i >= 0 {
+		return s[:i], s[i:]
+	}
+	return s, ""
+}
+
+// unquotePrefix decodes the Go quoted string at the start of s, returning the
+// unquoted string and the remainder of s.
+func unquotePrefix(s string) (str, rest string, err error) {
+	quoted, err := strconv.QuotedPrefix(s)
+	if err != nil {
+		return "", "", fmt.Errorf("expected quoted string at %q", s)
+	}
+	str, err = strconv.Unquote(quoted)
+	return str, s[len(quoted):], err
+}
+
+// unquoteList decodes a list of quoted strings as formatted by quoteList at the
+// start of s, returning the strings and the remainder of s.
+func unquoteList(s string) (strs []string, rest string, err error) {
+	if !strings.HasPrefix(s, "[") {
+		return nil, "", fmt.Errorf("expected [ at %q", s)
+	}
+	s = s[1:]
+	for {
+		s = strings.TrimLeft(s, " ")
+		if strings.HasPrefix(s, "]") {
+			return strs, s[1:], nil
+		}
+		if len(strs) > 0 {
+			if !strings.HasPrefix(s, ",") {
+				return nil, "", fmt.Errorf("expected , or ] at %q", s)
+			}
+			s = strings.TrimLeft(s[1:], " ")
+		}
+		var str string
+		if str, s, err = unquotePrefix(s); err != nil {
+			return nil, "", err
+		}
+		strs = append(strs, str)
+	}
+}
+
+// jsonCondition has the same fields as Condition but without its methods, so
+// the text encoding is not used when encoding Condition as JSON.
+type jsonCondition Condition
+
+// MarshalJSON implements the json.Marshaler interface, encoding the condition
+// as a JSON object rather than its text encoding. As with omitempty, empty
+// fields are not encoded, including zero times, which omitempty does not omit.
+func (c Condition) MarshalJSON() ([]byte, error) {
+	var (
+		buf bytes.Buffer
+		v   = reflect.ValueOf(jsonCondition(c))
+		t   = v.Type()
+	)
+	buf.WriteByte('{')
+	for i := 0; i < t.NumField(); i++ {
+		field, value := t.Field(i), v.Field(i)
+		if field.PkgPath != "" || field.Tag.Get("json") == "-" || isEmptyValue(value) {
+			continue
+		}
+		data, err := json.Marshal(value.Interface())
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %v", field.Name, err)
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(strconv.Quote(jsonName(field)))
+		buf.WriteByte(':')
+		buf.Write(data)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// isEmptyValue returns true if value is omitted by omitempty, or is a zero
+// struct, such as a zero time.Time.
+func isEmptyValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return value.Len() == 0
+	}
+	return value.IsZero()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, decoding a condition
+// encoded as a JSON object.
+func (c *Condition) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*jsonCondition)(c))
+}
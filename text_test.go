@@ -0,0 +1,166 @@
+package ghfilter
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCondition_text(t *testing.T) {
+	condition := Condition{
+		Negate:                  true,
+		Type:                    "IssuesEvent",
+		PayloadIssueTitleRegexp: `foo['"]\s+bar`,
+		PayloadActionAny:        []string{"opened", `re"opened`},
+		RepositoryID:            1,
+		ComparePublic:           true,
+		PayloadHeadCommitAfter:  time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	text, err := condition.MarshalText()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `Negate=true Type="IssuesEvent" PayloadIssueTitleRegexp="foo['\"]\\s+bar" ComparePublic=true RepositoryID=1 PayloadActionAny=["opened", "re\"opened"] PayloadHeadCommitAfter="2017-01-02T03:04:05Z"`
+	if string(text) != want {
+		t.Errorf("text does not match\nhave: %s\nwant: %s", text, want)
+	}
+
+	var have Condition
+	if err := have.UnmarshalText(text); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(have, condition) {
+		t.Errorf("round trip does not match\nhave: %+v\nwant: %+v", have, condition)
+	}
+}
+
+func TestCondition_textDuration(t *testing.T) {
+	condition := Condition{MaxAge: 90 * time.Minute}
+
+	text, err := condition.MarshalText()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `MaxAge=1h30m0s`; string(text) != want {
+		t.Errorf("text does not match\nhave: %s\nwant: %s", text, want)
+	}
+
+	var have Condition
+	if err := have.UnmarshalText(text); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(have, condition) {
+		t.Errorf("round trip does not match\nhave: %+v\nwant: %+v", have, condition)
+	}
+}
+
+func TestCondition_textRoundTrip(t *testing.T) {
+	// Ensure every field's type is supported by the text encoding
+	condition := populatedCondition()
+
+	text, err := condition.MarshalText()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var have Condition
+	if err := have.UnmarshalText(text); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(have, condition) {
+		t.Errorf("round trip does not match\nhave: %+v\nwant: %+v", have, condition)
+	}
+}
+
+func TestCondition_unmarshalTextErrors(t *testing.T) {
+	tests := []string{
+		`Type`,
+		`Unknown="x"`,
+		`Type=IssuesEvent`,
+		`Type="IssuesEvent`,
+		`RepositoryID=one`,
+		`Negate=truex`,
+		`MaxAge=1x`,
+		`PayloadActionAny=["a" "b"]`,
+		`Type="a"Negate=true`,
+	}
+
+	for _, text := range tests {
+		var c Condition
+		if err := c.UnmarshalText([]byte(text)); err == nil {
+			t.Errorf("expected error unmarshalling %q", text)
+		}
+	}
+}
+
+func TestCondition_jsonObject(t *testing.T) {
+	condition := Condition{Type: "IssuesEvent", RepositoryID: 1}
+
+	data, err := json.Marshal(condition)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(data) == 0 || data[0] != '{' {
+		t.Errorf("expected condition to be encoded as a JSON object, have: %s", data)
+	}
+
+	var have Condition
+	if err := json.Unmarshal(data, &have); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(have, condition) {
+		t.Errorf("round trip does not match\nhave: %+v\nwant: %+v", have, condition)
+	}
+}
+
+func TestCondition_jsonOmitsEmpty(t *testing.T) {
+	tests := []struct {
+		condition Condition
+		want      string
+	}{
+		{Condition{}, `{}`},
+		{Condition{Type: "IssuesEvent"}, `{"Type":"IssuesEvent"}`},
+		{Condition{CreatedAfter: time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC)}, `{"CreatedAfter":"2017-01-02T03:04:05Z"}`},
+		{Condition{PayloadActionAny: []string{}, RepositoryID: 1}, `{"RepositoryID":1}`},
+	}
+
+	for _, test := range tests {
+		data, err := json.Marshal(test.condition)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(data) != test.want {
+			t.Errorf("have: %s, want: %s", data, test.want)
+		}
+	}
+
+	data, err := json.Marshal(Filter{Root: NewOr(&Condition{Type: "IssuesEvent"})})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `{"Type":"IssuesEvent"}`; !strings.Contains(string(data), want) {
+		t.Errorf("root condition not encoded as %s, have: %s", want, data)
+	}
+}
+
+func TestCondition_marshalIgnoresFuncs(t *testing.T) {
+	condition := Condition{
+		Type:         "IssuesEvent",
+		ActorAllowed: func(string) bool { return true },
+	}
+
+	text, err := condition.MarshalText()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `Type="IssuesEvent"`; string(text) != want {
+		t.Errorf("text does not match\nhave: %s\nwant: %s", text, want)
+	}
+
+	if _, err := json.Marshal(condition); err != nil {
+		t.Errorf("unexpected error marshalling condition with func: %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package ghfilter
+
+import "fmt"
+
+// Validate returns an error if the condition can never be evaluated as
+// intended, such as an invalid regular expression, a minimum greater than its
+// maximum, or a compared value set without enabling its comparison.
+func (c *Condition) Validate() error {
+	if err := c.checkRegexps(); err != nil {
+		return err
+	}
+
+	for _, r := range []struct {
+		name     string
+		min, max int
+	}{
+		{"PayloadSize", c.PayloadSizeMin, c.PayloadSizeMax},
+		{"PayloadPullRequestCommits", c.PayloadPullRequestCommitsMin, c.PayloadPullRequestCommitsMax},
+		{"PayloadPushFilesChanged", c.PayloadPushFilesChangedMin, c.PayloadPushFilesChangedMax},
+		{"PayloadCommentLength", c.PayloadCommentLengthMin, c.PayloadCommentLengthMax},
+		{"PayloadCommitsCount", c.PayloadCommitsCountMin, c.PayloadCommitsCountMax},
+		{"PayloadRepositorySize", c.PayloadRepositorySizeMin, c.PayloadRepositorySizeMax},
+	} {
+		if r.min < 0 || r.max < 0 {
+			return fmt.Errorf("%sMin and %sMax must not be negative", r.name, r.name)
+		}
+		if r.max != 0 && r.min > r.max {
+			return fmt.Errorf("%sMin %d is greater than %sMax %d", r.name, r.min, r.name, r.max)
+		}
+	}
+
+	if !c.PayloadHeadCommitAfter.IsZero() && !c.PayloadHeadCommitBefore.IsZero() && !c.PayloadHeadCommitAfter.Before(c.PayloadHeadCommitBefore) {
+		return fmt.Errorf("PayloadHeadCommitAfter must be before PayloadHeadCommitBefore")
+	}
+	if !c.PayloadIssueMilestoneDueAfter.IsZero() && !c.PayloadIssueMilestoneDueBefore.IsZero() && !c.PayloadIssueMilestoneDueAfter.Before(c.PayloadIssueMilestoneDueBefore) {
+		return fmt.Errorf("PayloadIssueMilestoneDueAfter must be before PayloadIssueMilestoneDueBefore")
+	}
+	if !c.CreatedAfter.IsZero() && !c.CreatedBefore.IsZero() && !c.CreatedAfter.Before(c.CreatedBefore) {
+		return fmt.Errorf("CreatedAfter must be before CreatedBefore")
+	}
+
+	for _, b := range []struct {
+		name           string
+		compare, value bool
+	}{
+		{"Public", c.ComparePublic, c.Public},
+		{"PushCreated", c.ComparePushCreated, c.PushCreated},
+		{"PushDeleted", c.ComparePushDeleted, c.PushDeleted},
+		{"RepositoryArchived", c.CompareRepositoryArchived, c.RepositoryArchived},
+		{"IssueLocked", c.CompareIssueLocked, c.IssueLocked},
+	} {
+		if b.value && !b.compare {
+			return fmt.Errorf("%s is set but Compare%s is not", b.name, b.name)
+		}
+	}
+
+	if c.PayloadAction != "" && len(c.PayloadActionAny) > 0 && !containsFold(c.PayloadActionAny, c.PayloadAction) {
+		return fmt.Errorf("PayloadAction %q is not any of PayloadActionAny %s", c.PayloadAction, quoteList(c.PayloadActionAny))
+	}
+	if c.Category != "" {
+		types, ok := Categories[c.Category]
+		if !ok {
+			return fmt.Errorf("unknown Category %q", c.Category)
+		}
+		if c.Type != "" && !containsString(types, c.Type) {
+			return fmt.Errorf("Type %q is not in Category %q", c.Type, c.Category)
+		}
+		for _, t := range c.Types {
+			if !containsString(types, t) {
+				return fmt.Errorf("Types %q is not in Category %q", t, c.Category)
+			}
+		}
+	}
+	if c.PayloadSampleRate < 0 || c.PayloadSampleRate > 1 {
+		return fmt.Errorf("PayloadSampleRate %g must be between 0 and 1", c.PayloadSampleRate)
+	}
+	if c.MaxAge < 0 {
+		return fmt.Errorf("MaxAge must not be negative")
+	}
+	if c.BusinessHours != nil {
+		if err := c.BusinessHours.validate(); err != nil {
+			return err
+		}
+	}
+	if c.PayloadJSONPathBool != nil {
+		if err := c.PayloadJSONPathBool.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate returns an error if the filter's Logic is unknown, or any of its
+// conditions, including those in Root, are not valid, see Condition.Validate.
+func (f *Filter) Validate() error {
+	if f.Logic != And && f.Logic != Or {
+		return fmt.Errorf("unknown Logic %d", int(f.Logic))
+	}
+	for i := range f.Conditions {
+		if err := f.Conditions[i].Validate(); err != nil {
+			return fmt.Errorf("condition %d: %v", i, err)
+		}
+	}
+	if f.Root != nil {
+		var err error
+		f.Root.conditions(func(condition *Condition) {
+			if err == nil {
+				err = condition.Validate()
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("root: %v", err)
+		}
+	}
+	return nil
+}
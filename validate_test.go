@@ -0,0 +1,193 @@
+package ghfilter
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+// populatedCondition returns a valid condition with every serialisable field
+// set.
+func populatedCondition() Condition {
+	return Condition{
+		Negate:                              true,
+		Type:                                "IssuesEvent",
+		PayloadAction:                       "opened",
+		PayloadIssueLabel:                   "bug",
+		PayloadIssueMilestoneTitle:          "v1",
+		PayloadIssueTitleRegexp:             "^Title",
+		PayloadIssueBodyRegexp:              "Body$",
+		ComparePublic:                       true,
+		Public:                              true,
+		OrganizationID:                      1,
+		RepositoryID:                        2,
+		PayloadReactionContent:              "+1",
+		PayloadCommitCommentPathRegexp:      `\.go$`,
+		PayloadIssueAuthorAssociations:      []string{"MEMBER"},
+		PayloadSizeMin:                      1,
+		PayloadSizeMax:                      1000,
+		PayloadMembershipUserLogin:          "octocat",
+		PayloadPullRequestMilestoneTitle:    "v2",
+		PayloadActionAny:                    []string{"opened", "reopened"},
+		PayloadRepositoryTopicsAny:          []string{"go"},
+		Category:                            "issue",
+		PayloadHeadCommitAfter:              time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC),
+		PayloadHeadCommitBefore:             time.Date(2017, 2, 1, 0, 0, 0, 0, time.UTC),
+		ComparePushCreated:                  true,
+		PushCreated:                         true,
+		ComparePushDeleted:                  true,
+		PushDeleted:                         true,
+		PayloadPushDistinctAuthorsMin:       2,
+		PayloadBodyMentions:                 "bradleyfalzon",
+		PayloadSenderID:                     3,
+		PayloadPullRequestIsCrossRepo:       true,
+		CompareRepositoryArchived:           true,
+		RepositoryArchived:                  true,
+		PayloadProjectNameRegexp:            "^Roadmap",
+		PayloadPushTouchesPath:              "^docs/",
+		PayloadLabelName:                    "bug",
+		MaxAge:                              time.Hour,
+		PayloadDeploymentStatusState:        "failure",
+		PayloadCommentCommand:               "/deploy",
+		PayloadIssueMilestoneDueAfter:       time.Date(2017, 3, 1, 0, 0, 0, 0, time.UTC),
+		PayloadIssueMilestoneDueBefore:      time.Date(2017, 4, 1, 0, 0, 0, 0, time.UTC),
+		PayloadPullRequestCommitsMin:        1,
+		PayloadPullRequestCommitsMax:        10,
+		PayloadIssueBodyHasTaskList:         true,
+		PayloadPushFilesChangedMin:          1,
+		PayloadPushFilesChangedMax:          20,
+		PayloadRepositoryStarsMin:           100,
+		CompareIssueLocked:                  true,
+		IssueLocked:                         true,
+		PayloadCommentWasEdited:             true,
+		ActorLogin:                          "octocat",
+		ActorID:                             4,
+		PayloadPushIsTag:                    true,
+		BusinessHours:                       &BusinessHours{Start: 9, End: 17, Location: time.UTC},
+		RepositoryName:                      "owner/repo",
+		PayloadWorkflowJobConclusion:        "failure",
+		RepositoryNameGlob:                  "owner/*",
+		PayloadWorkflowJobLabelsAny:         []string{"self-hosted"},
+		PayloadActionTerminal:               true,
+		PayloadActionOpening:                true,
+		PayloadCommentLengthMin:             1,
+		PayloadCommentLengthMax:             280,
+		PayloadURLHostRegexp:                `^github\.com$`,
+		CaseSensitive:                       true,
+		PayloadPullRequestAction:            "closed",
+		PayloadPullRequestMerged:            github.Bool(true),
+		PayloadPullRequestBaseRef:           "main",
+		PayloadIssueTransferred:             true,
+		PayloadJSONPathBool:                 &JSONPathBool{Path: "pull_request.draft", Value: true},
+		PayloadPullRequestHeadRefRegexp:     `^release/`,
+		PayloadRef:                          "refs/heads/main",
+		PayloadRefRegexp:                    `^refs/heads/`,
+		PayloadCommitsCountMin:              1,
+		PayloadCommitsCountMax:              5,
+		PayloadCommitMessageRegexp:          `(?i)hotfix`,
+		PayloadCommentBodyRegexp:            `^/deploy\b`,
+		PayloadSenderIsRepoOwner:            true,
+		PayloadPackageEcosystem:             "npm",
+		PayloadIssueNumber:                  42,
+		PayloadIssueState:                   "closed",
+		PayloadSampleRate:                   0.1,
+		PayloadRepositorySizeMin:            100,
+		PayloadRepositorySizeMax:            100000,
+		PayloadIssueLabels:                  []string{"bug", "priority"},
+		Types:                               []string{"IssuesEvent"},
+		PayloadSecurityAdvisorySeverity:     "critical",
+		PayloadHasNoAction:                  true,
+		CreatedAfter:                        time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC),
+		CreatedBefore:                       time.Date(2017, 2, 1, 0, 0, 0, 0, time.UTC),
+		PayloadPullRequestRequestedReviewer: "octocat",
+	}
+}
+
+func TestFilter_jsonRoundTrip(t *testing.T) {
+	condition := populatedCondition()
+
+	// Ensure new fields are added to populatedCondition
+	v := reflect.ValueOf(condition)
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if field.PkgPath != "" || field.Tag.Get("json") == "-" {
+			continue
+		}
+		if v.Field(i).IsZero() {
+			t.Errorf("field %s is not set by populatedCondition", field.Name)
+		}
+	}
+
+	filter := Filter{
+		Conditions:   []Condition{condition, {Type: "PushEvent"}},
+		Logic:        Or,
+		Root:         NewOr(&Condition{Type: "IssuesEvent"}, NewAnd(&condition)),
+		StrictRegexp: true,
+		Includes:     []string{"other"},
+		ExcludeTypes: []string{"WatchEvent"},
+	}
+	if err := filter.Validate(); err != nil {
+		t.Fatalf("unexpected error validating filter: %v", err)
+	}
+
+	data, err := json.Marshal(filter)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded Filter
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, filter) {
+		t.Errorf("decoded filter does not match\nhave: %+v\nwant: %+v", decoded, filter)
+	}
+	if err := decoded.Validate(); err != nil {
+		t.Errorf("unexpected error validating decoded filter: %v", err)
+	}
+}
+
+func TestCondition_validate(t *testing.T) {
+	tests := []Condition{
+		{PayloadIssueTitleRegexp: "("},
+		{RepositoryNameGlob: "["},
+		{PayloadURLHostRegexp: "("},
+		{PayloadSizeMin: 10, PayloadSizeMax: 5},
+		{PayloadPullRequestCommitsMin: -1},
+		{PayloadPushFilesChangedMin: 3, PayloadPushFilesChangedMax: 2},
+		{PayloadCommentLengthMin: 281, PayloadCommentLengthMax: 280},
+		{PayloadHeadCommitAfter: time.Date(2017, 2, 1, 0, 0, 0, 0, time.UTC), PayloadHeadCommitBefore: time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{PayloadIssueMilestoneDueAfter: time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), PayloadIssueMilestoneDueBefore: time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Public: true},
+		{IssueLocked: true},
+		{PayloadAction: "closed", PayloadActionAny: []string{"opened"}},
+		{Category: "unknown"},
+		{Category: "push", Type: "IssuesEvent"},
+		{Category: "push", Types: []string{"PushEvent", "IssuesEvent"}},
+		{MaxAge: -time.Hour},
+		{CreatedAfter: time.Date(2017, 2, 1, 0, 0, 0, 0, time.UTC), CreatedBefore: time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{BusinessHours: &BusinessHours{Start: 9, End: 24}},
+		{PayloadJSONPathBool: &JSONPathBool{Path: ""}},
+		{PayloadSampleRate: -0.1},
+		{PayloadSampleRate: 1.5},
+		{PayloadJSONPathBool: &JSONPathBool{Path: "pull_request."}},
+	}
+
+	for _, condition := range tests {
+		if err := condition.Validate(); err == nil {
+			t.Errorf("expected error validating %v", condition)
+		}
+	}
+
+	if err := (&Condition{}).Validate(); err != nil {
+		t.Errorf("unexpected error validating empty condition: %v", err)
+	}
+	if err := (&Filter{Logic: 2}).Validate(); err == nil {
+		t.Errorf("expected error validating unknown logic")
+	}
+	if err := (&Filter{Root: NewOr(&Condition{Public: true})}).Validate(); err == nil {
+		t.Errorf("expected error validating root")
+	}
+}
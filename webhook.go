@@ -0,0 +1,184 @@
+package ghfilter
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+// WebhookHandler is a http.Handler receiving GitHub webhook deliveries and
+// calling a func for each delivery matching a Filter.
+//
+// Each delivery is converted to a github.Event with the Type derived from the
+// X-GitHub-Event header, such as IssuesEvent for issues, the RawPayload set to
+// the request body and the ID set to the X-GitHub-Delivery GUID. The Repo,
+// Public, Actor and Org fields are set from the payload's repository, sender
+// and organization fields, if present, and CreatedAt is set to the time the
+// delivery was received.
+//
+// Request bodies larger than MaxWebhookBodySize are rejected.
+type WebhookHandler struct {
+	filter  *Filter
+	onMatch func(*github.Event)
+
+	maxBodySize int64            // maxBodySize is the maximum size of a request body
+	now         func() time.Time // now is the time a delivery was received
+
+	mu         sync.Mutex
+	deliveries *lruCache // deliveries is nil unless redeliveries are skipped
+}
+
+// NewWebhookHandler returns a WebhookHandler calling onMatch with each delivery
+// matching filter.
+func NewWebhookHandler(filter *Filter, onMatch func(*github.Event)) *WebhookHandler {
+	return &WebhookHandler{
+		filter:      filter,
+		onMatch:     onMatch,
+		maxBodySize: MaxWebhookBodySize,
+		now:         time.Now,
+	}
+}
+
+// MaxWebhookBodySize is the maximum size of a webhook delivery's body, GitHub
+// caps payloads at 25 MB.
+const MaxWebhookBodySize = 25 << 20
+
+// SkipRedeliveries causes deliveries with a previously seen X-GitHub-Delivery
+// GUID to be ignored, such as those redelivered manually or after a timeout,
+// including while the first delivery is still being processed. A GUID is
+// forgotten if its delivery was not processed, such as when onMatch panics, so
+// it is processed again when redelivered. Only the most recent size GUIDs are
+// remembered.
+func (h *WebhookHandler) SkipRedeliveries(size int) {
+	h.mu.Lock()
+	h.deliveries = newLRUCache(size)
+	h.mu.Unlock()
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	eventType := r.Header.Get("X-GitHub-Event")
+	if eventType == "" {
+		http.Error(w, "missing X-GitHub-Event header", http.StatusBadRequest)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, h.maxBodySize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "could not read body", http.StatusBadRequest)
+		return
+	}
+	if !json.Valid(body) {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	delivery := r.Header.Get("X-GitHub-Delivery")
+	if delivery != "" && !h.reserve(delivery) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	processed := false
+	defer func() {
+		if delivery != "" && !processed {
+			h.release(delivery)
+		}
+	}()
+
+	payload := json.RawMessage(body)
+	received := h.now()
+	event := &github.Event{
+		Type:       github.String(webhookEventType(eventType)),
+		RawPayload: &payload,
+		CreatedAt:  &received,
+	}
+	if delivery != "" {
+		event.ID = github.String(delivery)
+	}
+	setWebhookEventFields(event, body)
+
+	if h.filter.Matches(event) {
+		h.onMatch(event)
+	}
+	processed = true
+	w.WriteHeader(http.StatusOK)
+}
+
+// reserve returns false if redeliveries are skipped and delivery has been
+// seen, else it records delivery as seen and returns true.
+func (h *WebhookHandler) reserve(delivery string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.deliveries == nil {
+		return true
+	}
+	if _, ok := h.deliveries.get(delivery); ok {
+		return false
+	}
+	h.deliveries.set(delivery, nil)
+	return true
+}
+
+// release forgets delivery, so it is processed again when redelivered.
+func (h *WebhookHandler) release(delivery string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.deliveries != nil {
+		h.deliveries.remove(delivery)
+	}
+}
+
+// setWebhookEventFields sets the event's Repo, Public, Actor and Org from the
+// payload's repository, sender and organization fields. Each field is decoded
+// separately, so a field which cannot be decoded does not prevent the others
+// being set.
+func setWebhookEventFields(event *github.Event, body []byte) {
+	var fields struct {
+		Repository   json.RawMessage `json:"repository"`
+		Sender       json.RawMessage `json:"sender"`
+		Organization json.RawMessage `json:"organization"`
+	}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return
+	}
+
+	var repo github.Repository
+	if len(fields.Repository) > 0 && json.Unmarshal(fields.Repository, &repo) == nil && repo.FullName != nil {
+		// Events name repositories by their full name, such as "owner/repo"
+		event.Repo = &github.Repository{ID: repo.ID, Name: repo.FullName, URL: repo.URL}
+		if repo.Private != nil {
+			event.Public = github.Bool(!*repo.Private)
+		}
+	}
+	var sender github.User
+	if len(fields.Sender) > 0 && json.Unmarshal(fields.Sender, &sender) == nil && sender.Login != nil {
+		event.Actor = &sender
+	}
+	var org github.Organization
+	if len(fields.Organization) > 0 && json.Unmarshal(fields.Organization, &org) == nil && org.Login != nil {
+		event.Org = &org
+	}
+}
+
+// webhookEventType converts a webhook event name, such as pull_request, to an
+// event type, such as PullRequestEvent.
+func webhookEventType(name string) string {
+	var eventType string
+	for _, word := range strings.Split(name, "_") {
+		if word != "" {
+			eventType += strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return eventType + "Event"
+}
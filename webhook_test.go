@@ -0,0 +1,177 @@
+package ghfilter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+func deliver(t *testing.T, h http.Handler, eventType, delivery, body string) {
+	r := httptest.NewRequest("POST", "/", strings.NewReader(body))
+	r.Header.Set("X-GitHub-Event", eventType)
+	r.Header.Set("X-GitHub-Delivery", delivery)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("have status %v, want %v", w.Code, http.StatusOK)
+	}
+}
+
+func TestWebhookHandler(t *testing.T) {
+	var matched []string
+	h := NewWebhookHandler(&Filter{
+		Conditions: []Condition{{Type: "IssuesEvent", PayloadAction: "opened"}},
+	}, func(event *github.Event) {
+		matched = append(matched, event.GetID())
+	})
+
+	deliver(t, h, "issues", "guid-1", `{"action":"opened"}`)
+	deliver(t, h, "issues", "guid-2", `{"action":"closed"}`)
+	deliver(t, h, "pull_request", "guid-3", `{"action":"opened"}`)
+	deliver(t, h, "issues", "guid-1", `{"action":"opened"}`)
+
+	if want := "guid-1,guid-1"; strings.Join(matched, ",") != want {
+		t.Errorf("have matches %v, want %v", matched, want)
+	}
+}
+
+func TestWebhookHandler_skipRedeliveries(t *testing.T) {
+	var matched []string
+	h := NewWebhookHandler(&Filter{}, func(event *github.Event) {
+		matched = append(matched, event.GetID())
+	})
+	h.SkipRedeliveries(2)
+
+	deliver(t, h, "issues", "guid-1", `{}`)
+	deliver(t, h, "issues", "guid-1", `{}`) // redelivery
+	deliver(t, h, "issues", "guid-2", `{}`)
+	deliver(t, h, "issues", "guid-3", `{}`) // guid-1 is forgotten
+	deliver(t, h, "issues", "guid-1", `{}`)
+
+	if want := "guid-1,guid-2,guid-3,guid-1"; strings.Join(matched, ",") != want {
+		t.Errorf("have matches %v, want %v", matched, want)
+	}
+}
+
+func TestWebhookHandler_skipRedeliveriesAfterPanic(t *testing.T) {
+	var matched []string
+	panics := true
+	h := NewWebhookHandler(&Filter{}, func(event *github.Event) {
+		if panics {
+			panics = false
+			panic("onMatch failed")
+		}
+		matched = append(matched, event.GetID())
+	})
+	h.SkipRedeliveries(2)
+
+	func() {
+		defer func() { recover() }()
+		deliver(t, h, "issues", "guid-1", `{}`)
+	}()
+	deliver(t, h, "issues", "guid-1", `{}`) // redelivery of unprocessed delivery
+	deliver(t, h, "issues", "guid-1", `{}`) // redelivery of processed delivery
+
+	if want := "guid-1"; strings.Join(matched, ",") != want {
+		t.Errorf("have matches %v, want %v", matched, want)
+	}
+}
+
+func TestWebhookHandler_skipConcurrentRedeliveries(t *testing.T) {
+	var (
+		calls   int32
+		started = make(chan struct{})
+		finish  = make(chan struct{})
+	)
+	h := NewWebhookHandler(&Filter{}, func(event *github.Event) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			close(started)
+			<-finish
+		}
+	})
+	h.SkipRedeliveries(2)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		deliver(t, h, "issues", "guid-1", `{}`)
+	}()
+	<-started
+	deliver(t, h, "issues", "guid-1", `{}`) // redelivered while processing
+	close(finish)
+	<-done
+
+	if have := atomic.LoadInt32(&calls); have != 1 {
+		t.Errorf("have %v calls, want 1", have)
+	}
+}
+
+func TestWebhookHandler_bodyTooLarge(t *testing.T) {
+	h := NewWebhookHandler(&Filter{}, func(event *github.Event) {
+		t.Errorf("unexpected match for body larger than limit")
+	})
+	h.maxBodySize = 8
+
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"action":"opened"}`))
+	r.Header.Set("X-GitHub-Event", "issues")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("have status %v, want %v", w.Code, http.StatusRequestEntityTooLarge)
+	}
+}
+
+func TestWebhookHandler_eventFields(t *testing.T) {
+	received := time.Now()
+	const body = `{
+		"action": "opened",
+		"repository": {"id": 1, "name": "repo", "full_name": "owner/repo", "private": false},
+		"sender": {"id": 2, "login": "octocat"},
+		"organization": {"id": 3, "login": "owner"}
+	}`
+
+	tests := []struct {
+		Condition Condition
+		Want      bool
+	}{
+		{Condition{RepositoryID: 1}, true},
+		{Condition{RepositoryName: "owner/repo"}, true},
+		{Condition{RepositoryName: "repo"}, false},
+		{Condition{ComparePublic: true, Public: true}, true},
+		{Condition{ActorLogin: "octocat"}, true},
+		{Condition{ActorID: 2}, true},
+		{Condition{OrganizationID: 3}, true},
+		{Condition{CreatedAfter: received.Add(-time.Hour), CreatedBefore: received.Add(time.Hour)}, true},
+		{Condition{MaxAge: time.Hour}, true},
+	}
+
+	for _, test := range tests {
+		var have bool
+		h := NewWebhookHandler(&Filter{Conditions: []Condition{test.Condition}}, func(event *github.Event) {
+			have = true
+		})
+		h.now = func() time.Time { return received }
+		deliver(t, h, "issues", "guid-1", body)
+		if have != test.Want {
+			t.Errorf("condition %+v have match %v, want %v", test.Condition, have, test.Want)
+		}
+	}
+}
+
+func TestWebhookEventType(t *testing.T) {
+	tests := map[string]string{
+		"issues":                      "IssuesEvent",
+		"pull_request":                "PullRequestEvent",
+		"pull_request_review_comment": "PullRequestReviewCommentEvent",
+	}
+	for name, want := range tests {
+		if have := webhookEventType(name); have != want {
+			t.Errorf("webhookEventType(%q) have: %v, want: %v", name, have, want)
+		}
+	}
+}